@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tom-023/tftaglint/internal/config"
+	"github.com/tom-023/tftaglint/internal/parser"
+	"github.com/tom-023/tftaglint/internal/reporter"
+	"github.com/tom-023/tftaglint/internal/validator"
+)
+
+// runTagChanges reports the tag keys each plan removes or changes, as recorded
+// in the before and after values of its resource_changes section, and fails
+// when tags protected by the plan's rules at or above the minimum severity are
+// affected
+func runTagChanges(validators []*validator.Validator, plans []input, minSeverity config.Severity) error {
+	var changes []validator.TagChange
+	for i, plan := range plans {
+		resourceChanges, err := parsePlanChanges(plan, parser.ResourceChanges)
+		if err != nil {
+			return fmt.Errorf("failed to parse terraform plan %s: %w", plan.name, err)
+		}
+		changes = append(changes, validators[i].ValidateTagChanges(resourceChanges)...)
+	}
+
+	r := reporter.NewReporter(os.Stdout)
+	if err := r.ReportTagChanges(changes); err != nil {
+		return fmt.Errorf("failed to report tag changes: %w", err)
+	}
+
+	failing := 0
+	for _, c := range changes {
+		failing += countFailing(c.Violations, minSeverity)
+	}
+	if failing > 0 {
+		return fmt.Errorf("found %d protected tag violations at or above severity %s", failing, minSeverity)
+	}
+
+	return nil
+}
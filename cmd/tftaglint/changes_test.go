@@ -0,0 +1,101 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRunValidate_TagChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		plan       string
+		wantOutput []string
+		wantErr    bool
+	}{
+		{
+			name: "protected tag removed",
+			plan: `{
+  "resource_changes": [
+    {
+      "address": "aws_instance.web",
+      "type": "aws_instance",
+      "name": "web",
+      "change": {
+        "actions": ["update"],
+        "before": {"tags": {"CostCenter": "CC-1", "Name": "web"}},
+        "after": {"tags": {"Name": "web"}}
+      }
+    }
+  ]
+}`,
+			wantOutput: []string{
+				"📝 Found tag changes on 1 resource(s):",
+				"finance-tags: Protected tag removed: CostCenter (was 'CC-1')",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unprotected tag changed",
+			plan: `{
+  "resource_changes": [
+    {
+      "address": "aws_instance.web",
+      "type": "aws_instance",
+      "name": "web",
+      "change": {
+        "actions": ["update"],
+        "before": {"tags": {"CostCenter": "CC-1", "Name": "web"}},
+        "after": {"tags": {"CostCenter": "CC-1", "Name": "web-1"}}
+      }
+    }
+  ]
+}`,
+			wantOutput: []string{`~ Name: "web" → "web-1"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			configPath := filepath.Join(tmpDir, "config.yaml")
+			err := os.WriteFile(configPath, []byte(`
+rules:
+  - name: finance-tags
+    protected_tags: [CostCenter]
+`), 0644)
+			if err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+			planPath := filepath.Join(tmpDir, "plan.json")
+			if err := os.WriteFile(planPath, []byte(tt.plan), 0644); err != nil {
+				t.Fatalf("Failed to write plan file: %v", err)
+			}
+
+			configFiles = []string{configPath}
+			planFiles = []string{planPath}
+			changesMode = true
+			defer func() {
+				configFiles = nil
+				planFiles = nil
+				changesMode = false
+			}()
+
+			output, err := captureOutput(t, func() error {
+				return runValidate(&cobra.Command{}, nil)
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runValidate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, but it doesn't.\nOutput:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
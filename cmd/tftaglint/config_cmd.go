@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tom-023/tftaglint/internal/config"
+	"gopkg.in/yaml.v3"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+}
+
+var configPrintCmd = &cobra.Command{
+	Use:   "print [dir]",
+	Short: "Print the effective configuration",
+	Long: `Print the configuration that validate uses for Terraform files in dir (default: the current directory), after resolving extends and layering the files given with -c, or else the files discovered from dir up to the repository root, in order.
+Rules are listed in the order they are checked; disabled rules and the extends and replace keys are resolved away.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigPrint,
+}
+
+func init() {
+	configPrintCmd.Flags().StringArrayVarP(&configFiles, "config", "c", nil, "Path to the configuration file (repeatable, later files are layered over earlier ones; default: discovered from dir)")
+	configCmd.AddCommand(configPrintCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+func runConfigPrint(cmd *cobra.Command, args []string) error {
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+
+	files := configFiles
+	if len(files) == 0 {
+		var err error
+		if files, err = config.FindConfigFiles(dir); err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+	}
+
+	cfg, err := config.LoadConfigs(files)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(cfg); err != nil {
+		return fmt.Errorf("failed to print config: %w", err)
+	}
+	return encoder.Close()
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunConfigPrint(t *testing.T) {
+	base := `rules:
+  - name: require-owner
+    required_tags: [Owner]
+  - name: require-cost-center
+    required_tags: [CostCenter]
+global:
+  always_required_tags: [Name]
+  ignore_resource_types: [aws_iam_role]
+`
+	team := `extends: [base.yaml]
+rules:
+  - name: require-owner
+    required_tags: [Owner, Team]
+  - name: require-cost-center
+    disabled: true
+global:
+  always_required_tags: [Environment]
+  ignore_resource_types: [aws_kms_key]
+  replace: [ignore_resource_types]
+`
+	override := `global:
+  severity: warning
+`
+
+	tests := []struct {
+		name        string
+		files       []string
+		wantOutput  []string
+		notInOutput []string
+		wantErr     bool
+	}{
+		{
+			name:  "extends",
+			files: []string{"team.yaml"},
+			wantOutput: []string{
+				"required_tags:\n      - Owner\n      - Team",
+				"always_required_tags:\n    - Name\n    - Environment",
+				"ignore_resource_types:\n    - aws_kms_key\n",
+			},
+			notInOutput: []string{"require-cost-center", "aws_iam_role", "extends", "replace", "disabled"},
+		},
+		{
+			name:       "several files",
+			files:      []string{"team.yaml", "override.yaml"},
+			wantOutput: []string{"severity: warning", "- name: require-owner"},
+		},
+		{
+			name:    "missing file",
+			files:   []string{"missing.yaml"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			for name, content := range map[string]string{"base.yaml": base, "team.yaml": team, "override.yaml": override} {
+				if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write file: %v", err)
+				}
+			}
+
+			configFiles = nil
+			for _, name := range tt.files {
+				configFiles = append(configFiles, filepath.Join(tmpDir, name))
+			}
+			defer func() { configFiles = nil }()
+
+			output, err := captureOutput(t, func() error {
+				return runConfigPrint(configPrintCmd, nil)
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runConfigPrint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, but it doesn't.\nOutput:\n%s", want, output)
+				}
+			}
+			for _, unwanted := range tt.notInOutput {
+				if strings.Contains(output, unwanted) {
+					t.Errorf("Expected output not to contain %q.\nOutput:\n%s", unwanted, output)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tom-023/tftaglint/internal/parser"
+	"github.com/tom-023/tftaglint/internal/reporter"
+	"github.com/tom-023/tftaglint/internal/tagdiff"
+)
+
+var diffFormat string
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <before> <after>",
+	Short: "Compare resource tags between two plans or states",
+	Long: `Compare the tags of every resource between two terraform plan or state files (plan JSON, binary plan, state JSON or raw terraform.tfstate); use - to read one of them from stdin.
+Resources are matched by address; moves recorded in the second file (for example by moved blocks) are honored.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func init() {
+	diffCmd.Flags().StringVar(&diffFormat, "format", "text", "Output format (text or json)")
+	diffCmd.Flags().StringVar(&terraformBin, "terraform-bin", "terraform", "Terraform or OpenTofu binary used to convert binary plan files to JSON")
+	diffCmd.Flags().StringVar(&terraformDir, "terraform-dir", "", "Working directory for converting binary plan files (default: directory of the plan file)")
+	rootCmd.AddCommand(diffCmd)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	if diffFormat != "text" && diffFormat != "json" {
+		return fmt.Errorf("unsupported format %q (use text or json)", diffFormat)
+	}
+
+	inputs, cleanup, err := prepareInputs(args, "")
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+	beforeInput, afterInput := inputs[0], inputs[1]
+
+	before, err := parseSnapshot(beforeInput)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", beforeInput.name, err)
+	}
+	after, err := parseSnapshot(afterInput)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", afterInput.name, err)
+	}
+
+	// Moves are recorded as previous_address in a plan's resource_changes
+	changes, err := parsePlanChanges(afterInput, parser.ResourceChanges)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", afterInput.name, err)
+	}
+	moved := make(map[string]string)
+	for _, change := range changes {
+		if change.PreviousAddress != "" {
+			moved[change.Address] = change.PreviousAddress
+		}
+	}
+
+	diffs := tagdiff.CompareResources(before.Resources, after.Resources, moved)
+
+	r := reporter.NewReporter(os.Stdout)
+	if diffFormat == "json" {
+		err = r.ReportResourceDiffsJSON(beforeInput.name, afterInput.name, diffs)
+	} else {
+		err = r.ReportResourceDiffs(beforeInput.name, afterInput.name, diffs)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to report differences: %w", err)
+	}
+
+	if len(diffs) > 0 {
+		return fmt.Errorf("found tag differences on %d resources", len(diffs))
+	}
+
+	return nil
+}
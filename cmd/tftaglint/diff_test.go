@@ -0,0 +1,118 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunDiff(t *testing.T) {
+	before := `{
+  "version": 4,
+  "resources": [
+    {"mode": "managed", "type": "aws_s3_bucket", "name": "logs", "instances": [{"attributes": {"tags": {"Name": "logs", "CostCenter": "CC-1"}}}]},
+    {"mode": "managed", "type": "aws_instance", "name": "web", "instances": [{"attributes": {"tags": {"Name": "web"}}}]}
+  ]
+}`
+	after := `{
+  "planned_values": {
+    "root_module": {
+      "resources": [
+        {"address": "aws_instance.web", "type": "aws_instance", "name": "web", "values": {"tags": {"Name": "web"}}}
+      ],
+      "child_modules": [
+        {
+          "address": "module.storage",
+          "resources": [
+            {"address": "module.storage.aws_s3_bucket.logs", "type": "aws_s3_bucket", "name": "logs", "values": {"tags": {"Name": "logs"}}}
+          ]
+        }
+      ]
+    }
+  },
+  "resource_changes": [
+    {"address": "module.storage.aws_s3_bucket.logs", "previous_address": "aws_s3_bucket.logs", "change": {"actions": ["update"]}}
+  ]
+}`
+
+	tests := []struct {
+		name       string
+		before     string
+		after      string
+		format     string
+		wantOutput []string
+		wantErr    bool
+	}{
+		{
+			name:   "moved resource with removed tag",
+			before: before,
+			after:  after,
+			format: "text",
+			wantOutput: []string{
+				"~ module.storage.aws_s3_bucket.logs (moved from aws_s3_bucket.logs)",
+				`- CostCenter (was "CC-1")`,
+			},
+			wantErr: true,
+		},
+		{
+			name:       "json output",
+			before:     before,
+			after:      after,
+			format:     "json",
+			wantOutput: []string{`"previous_address": "aws_s3_bucket.logs"`},
+			wantErr:    true,
+		},
+		{
+			name:       "identical files",
+			before:     before,
+			after:      before,
+			format:     "text",
+			wantOutput: []string{"✅ No tag differences found!"},
+		},
+		{
+			name:    "unsupported format",
+			before:  before,
+			after:   before,
+			format:  "yaml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			beforePath := filepath.Join(tmpDir, "before.tfstate")
+			afterPath := filepath.Join(tmpDir, "after.json")
+			if err := os.WriteFile(beforePath, []byte(tt.before), 0644); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+			if err := os.WriteFile(afterPath, []byte(tt.after), 0644); err != nil {
+				t.Fatalf("Failed to write file: %v", err)
+			}
+
+			diffFormat = tt.format
+			defer func() { diffFormat = "text" }()
+
+			output, err := captureOutput(t, func() error {
+				return runDiff(diffCmd, []string{beforePath, afterPath})
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runDiff() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, but it doesn't.\nOutput:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestRunDiff_FileNotFound(t *testing.T) {
+	err := runDiff(diffCmd, []string{"/non/existent/a.json", "/non/existent/b.json"})
+	if err == nil {
+		t.Error("Expected error for non-existent file")
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tom-023/tftaglint/internal/config"
+	"github.com/tom-023/tftaglint/internal/parser"
+	"github.com/tom-023/tftaglint/internal/validator"
+)
+
+// validatorSet creates a validator for each config in use. The files given
+// with -c apply everywhere; without them each directory uses the configs
+// discovered from it up to the repository root.
+type validatorSet struct {
+	now        time.Time                       // Reference time for expiry checks, zero for the current time
+	validators map[string]*validator.Validator // By the config files they were loaded from
+	files      map[string][]string             // Discovered config files by directory
+}
+
+func newValidatorSet(now time.Time) *validatorSet {
+	return &validatorSet{
+		now:        now,
+		validators: make(map[string]*validator.Validator),
+		files:      make(map[string][]string),
+	}
+}
+
+// forDir returns the validator for the configs that apply to dir
+func (s *validatorSet) forDir(dir string) (*validator.Validator, error) {
+	files := configFiles
+	if len(files) == 0 {
+		var ok bool
+		if files, ok = s.files[dir]; !ok {
+			var err error
+			files, err = config.FindConfigFiles(dir)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load config: %w", err)
+			}
+			s.files[dir] = files
+		}
+	}
+
+	key := strings.Join(files, "\n")
+	if v, ok := s.validators[key]; ok {
+		return v, nil
+	}
+
+	cfg, err := config.LoadConfigs(files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	v := validator.NewValidator(cfg)
+	if !s.now.IsZero() {
+		v.SetNow(s.now)
+	}
+	s.validators[key] = v
+	return v, nil
+}
+
+// validate validates each resource with the validator for the directory of its file
+func (s *validatorSet) validate(resources []parser.Resource) ([]validator.Violation, error) {
+	var violations []validator.Violation
+	for _, resource := range resources {
+		v, err := s.forDir(filepath.Dir(resource.File))
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, v.Validate([]parser.Resource{resource})...)
+	}
+	return violations, nil
+}
+
+// forInputs returns the validator for each plan or state input: the one for
+// the directory of its file, or for the working directory when read from stdin
+func (s *validatorSet) forInputs(inputs []input) ([]*validator.Validator, error) {
+	validators := make([]*validator.Validator, len(inputs))
+	for i, in := range inputs {
+		dir := "."
+		if !in.stdin {
+			dir = filepath.Dir(in.path)
+		}
+		v, err := s.forDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		validators[i] = v
+	}
+	return validators, nil
+}
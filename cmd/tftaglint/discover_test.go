@@ -0,0 +1,99 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRunValidate_DiscoveredConfigs(t *testing.T) {
+	repo := t.TempDir()
+	files := map[string]string{
+		".git/HEAD":                            "ref: refs/heads/main\n",
+		"tag-rules.yaml":                       "global:\n  always_required_tags: [Owner]\n",
+		"aws/account_prod/.tftaglint.yaml":     "rules:\n  - name: prod-environment\n    required_tags: [Environment]\n",
+		"aws/account_prod/vpc/main.tf":         `resource "aws_vpc" "main" {}`,
+		"gcp/analytics/main.tf":                `resource "google_bigquery_dataset" "events" {}`,
+		"gcp/analytics/modules/bucket/main.tf": `resource "google_storage_bucket" "raw" {}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	output, err := captureOutput(t, func() error {
+		return runValidate(&cobra.Command{}, []string{repo})
+	})
+	if err == nil || !strings.Contains(err.Error(), "found 4 tag violations") {
+		t.Errorf("Expected 4 violations, got error %v", err)
+	}
+	if got := strings.Count(output, "Missing required tag: Owner"); got != 3 {
+		t.Errorf("Expected the root config to apply to all 3 resources, got %d Owner violations.\nOutput:\n%s", got, output)
+	}
+	if got := strings.Count(output, "Missing required tag: Environment"); got != 1 {
+		t.Errorf("Expected the account_prod config to apply to 1 resource, got %d Environment violations.\nOutput:\n%s", got, output)
+	}
+}
+
+func TestRunValidate_DiscoveredConfigsForPlans(t *testing.T) {
+	repo := t.TempDir()
+	plan := `{"planned_values": {"root_module": {"resources": [
+  {"address": "aws_vpc.main", "type": "aws_vpc", "name": "main", "values": {"tags": {}}}
+]}}}`
+	files := map[string]string{
+		".git/HEAD":                       "ref: refs/heads/main\n",
+		"tag-rules.yaml":                  "global:\n  always_required_tags: [Owner]\n",
+		"aws/account_prod/tag-rules.yaml": "rules:\n  - name: prod-environment\n    required_tags: [Environment]\n",
+		"aws/account_prod/plan.json":      plan,
+		"aws/account_staging/plan.json":   plan,
+	}
+	for name, content := range files {
+		path := filepath.Join(repo, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+	planFiles = []string{filepath.Join(repo, "aws/*/plan.json")}
+	defer func() { planFiles = nil }()
+
+	output, err := captureOutput(t, func() error {
+		return runValidate(&cobra.Command{}, nil)
+	})
+	if err == nil || !strings.Contains(err.Error(), "found 3 tag violations") {
+		t.Errorf("Expected 3 violations, got error %v", err)
+	}
+	if got := strings.Count(output, "Missing required tag: Owner"); got != 2 {
+		t.Errorf("Expected the root config to apply to both plans, got %d Owner violations.\nOutput:\n%s", got, output)
+	}
+	if got := strings.Count(output, "Missing required tag: Environment"); got != 1 {
+		t.Errorf("Expected the account_prod config to apply to its plan only, got %d Environment violations.\nOutput:\n%s", got, output)
+	}
+}
+
+func TestRunValidate_NoConfigFound(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.Mkdir(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "main.tf"), []byte(`resource "aws_vpc" "main" {}`), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	_, err := captureOutput(t, func() error {
+		return runValidate(&cobra.Command{}, []string{repo})
+	})
+	if err == nil || !strings.Contains(err.Error(), "no config file (tag-rules.yaml, tag-rules.json") {
+		t.Errorf("Expected config not found error, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/tom-023/tftaglint/internal/config"
+	"github.com/tom-023/tftaglint/internal/parser"
+	"github.com/tom-023/tftaglint/internal/reporter"
+	"github.com/tom-023/tftaglint/internal/validator"
+)
+
+// runDrift reports resources whose tags were changed outside Terraform, as
+// recorded in the resource_drift section of each plan, and fails when the
+// out-of-band tags break the plan's rules at or above the minimum severity
+func runDrift(validators []*validator.Validator, plans []input, minSeverity config.Severity) error {
+	var drifts []validator.Drift
+	for i, plan := range plans {
+		changes, err := parsePlanChanges(plan, parser.ResourceDrift)
+		if err != nil {
+			return fmt.Errorf("failed to parse terraform plan %s: %w", plan.name, err)
+		}
+		drifts = append(drifts, validators[i].ValidateDrift(changes)...)
+	}
+
+	r := reporter.NewReporter(os.Stdout)
+	if err := r.ReportDrift(drifts); err != nil {
+		return fmt.Errorf("failed to report drift: %w", err)
+	}
+
+	failing := 0
+	for _, d := range drifts {
+		failing += countFailing(d.Violations, minSeverity)
+	}
+	if failing > 0 {
+		return fmt.Errorf("found %d drifted tag violations at or above severity %s", failing, minSeverity)
+	}
+
+	return nil
+}
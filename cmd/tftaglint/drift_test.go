@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// captureOutput runs fn with stdout and stderr redirected and returns what was written
+func captureOutput(t *testing.T, fn func() error) (string, error) {
+	t.Helper()
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	os.Stdout, os.Stderr = w, w
+
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+
+	fnErr := fn()
+	w.Close()
+	os.Stdout, os.Stderr = oldStdout, oldStderr
+	return <-done, fnErr
+}
+
+func TestRunValidate_Drift(t *testing.T) {
+	const driftPlan = `{
+  "resource_drift": [
+    {
+      "address": "aws_instance.web",
+      "type": "aws_instance",
+      "name": "web",
+      "change": {
+        "actions": ["update"],
+        "before": {"tags": {"Owner": "team-a", "Environment": "production"}},
+        "after": {"tags": {"Owner": "team-a", "Environment": "%s"}}
+      }
+    }
+  ]
+}`
+
+	tests := []struct {
+		name       string
+		severity   string
+		plan       string
+		noPlan     bool
+		wantOutput []string
+		wantErr    bool
+	}{
+		{
+			name: "tags changed outside terraform",
+			plan: fmt.Sprintf(driftPlan, "prod"),
+			wantOutput: []string{
+				"Found tag drift on 1 resource(s)",
+				`~ Environment: "production" → "prod"`,
+				"Invalid value for tag Environment: 'prod'",
+			},
+			wantErr: true,
+		},
+		{
+			name: "drift without violations does not fail",
+			plan: fmt.Sprintf(driftPlan, "staging"),
+			wantOutput: []string{
+				"Found tag drift on 1 resource(s)",
+				`~ Environment: "production" → "staging"`,
+			},
+		},
+		{
+			name:     "drift violations below fail-on do not fail",
+			severity: "warning",
+			plan:     fmt.Sprintf(driftPlan, "prod"),
+			wantOutput: []string{
+				"Invalid value for tag Environment: 'prod'",
+			},
+		},
+		{
+			name:       "no drift",
+			plan:       `{"resource_drift": []}`,
+			wantOutput: []string{"✅ No tag drift found!"},
+		},
+		{
+			name:    "drift requires a plan",
+			noPlan:  true,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			configPath := filepath.Join(tmpDir, "config.yaml")
+			severity := tt.severity
+			if severity == "" {
+				severity = "error"
+			}
+			err := os.WriteFile(configPath, []byte(`
+rules:
+  - name: valid-environment-values
+    severity: `+severity+`
+    tag_constraints:
+      - tag: Environment
+        allowed_values: [development, staging, production]
+`), 0644)
+			if err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+			configFiles = []string{configPath}
+			driftMode = true
+			defer func() {
+				configFiles = nil
+				driftMode = false
+				planFiles = nil
+			}()
+
+			if !tt.noPlan {
+				planPath := filepath.Join(tmpDir, "plan.json")
+				if err := os.WriteFile(planPath, []byte(tt.plan), 0644); err != nil {
+					t.Fatalf("Failed to write plan file: %v", err)
+				}
+				planFiles = []string{planPath}
+			}
+
+			output, err := captureOutput(t, func() error {
+				return runValidate(&cobra.Command{}, nil)
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runValidate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, but it doesn't.\nOutput:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
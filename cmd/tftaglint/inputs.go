@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/tom-023/tftaglint/internal/parser"
+)
+
+// stdinArg is the plan or state argument that reads from standard input
+const stdinArg = "-"
+
+// input is a plan or state file named on the command line
+type input struct {
+	path  string // File to read; a temporary copy when reading stdin
+	name  string // Name shown in reports
+	stdin bool
+}
+
+// prepareInputs resolves plan or state arguments into inputs. Standard input
+// ("-") is copied to a temporary file so it can be read like any other input;
+// the returned cleanup function removes it. displayName, when set, replaces
+// the file path in reports and is only allowed for a single input.
+func prepareInputs(args []string, displayName string) ([]input, func(), error) {
+	cleanup := func() {}
+	if displayName != "" && len(args) > 1 {
+		return nil, cleanup, fmt.Errorf("a display name can only be set for a single input, got %d", len(args))
+	}
+
+	inputs := make([]input, 0, len(args))
+	for _, arg := range args {
+		in := input{path: arg, name: arg}
+		if arg == stdinArg {
+			if inputsUseStdin(inputs) {
+				return nil, cleanup, fmt.Errorf("standard input can only be read once")
+			}
+			path, err := copyStdin()
+			if err != nil {
+				return nil, cleanup, err
+			}
+			cleanup = func() { os.Remove(path) }
+			in = input{path: path, name: "stdin", stdin: true}
+		}
+		if displayName != "" {
+			in.name = displayName
+		}
+		inputs = append(inputs, in)
+	}
+
+	return inputs, cleanup, nil
+}
+
+func inputsUseStdin(inputs []input) bool {
+	for _, in := range inputs {
+		if in.stdin {
+			return true
+		}
+	}
+	return false
+}
+
+// copyStdin writes standard input to a temporary file and returns its path
+func copyStdin() (string, error) {
+	file, err := os.CreateTemp("", "tftaglint-stdin-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to buffer standard input: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, os.Stdin); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to read standard input: %w", err)
+	}
+
+	return file.Name(), nil
+}
+
+// binaryPlanDir returns the working directory for converting a binary plan.
+// Plans read from stdin are converted in the current directory rather than
+// next to the temporary copy.
+func (in input) binaryPlanDir() string {
+	if terraformDir == "" && in.stdin {
+		return "."
+	}
+	return terraformDir
+}
+
+// rename points a resource parsed from the input at the input's display name
+func (in input) rename(resource *parser.Resource) {
+	if resource == nil {
+		return
+	}
+	resource.File = in.name
+	resource.Location.Filename = in.name
+}
+
+func (in input) renameAll(resources []parser.Resource) {
+	for i := range resources {
+		in.rename(&resources[i])
+	}
+}
+
+// parsePlanFile parses a plan JSON file, converting binary plans with terraform show
+func parsePlanFile(in input) (*parser.ParseResult, error) {
+	parseResult, err := parser.ParseTerraformPlan(in.path)
+	if errors.Is(err, parser.ErrBinaryPlan) {
+		parseResult, err = parser.ParseBinaryPlan(in.path, terraformBin, in.binaryPlanDir())
+	}
+	if err != nil {
+		return nil, err
+	}
+	in.renameAll(parseResult.Resources)
+	return parseResult, nil
+}
+
+// parsePlanChanges reads a change section from a plan file, converting binary
+// plans with terraform show
+func parsePlanChanges(in input, section parser.ChangeSection) ([]parser.ResourceChange, error) {
+	changes, err := parser.ParseTerraformPlanChanges(in.path, section)
+	if errors.Is(err, parser.ErrBinaryPlan) {
+		changes, err = parser.ParseBinaryPlanChanges(in.path, section, terraformBin, in.binaryPlanDir())
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range changes {
+		in.rename(changes[i].Before)
+		in.rename(changes[i].After)
+	}
+	return changes, nil
+}
+
+// parseStateFile parses a raw tfstate or `terraform show -json` state file
+func parseStateFile(in input) (*parser.ParseResult, error) {
+	parseResult, err := parser.ParseTerraformState(in.path)
+	if err != nil {
+		return nil, err
+	}
+	in.renameAll(parseResult.Resources)
+	return parseResult, nil
+}
+
+// parseSnapshot parses a plan or state file, converting binary plans with terraform show
+func parseSnapshot(in input) (*parser.ParseResult, error) {
+	parseResult, err := parser.ParseTerraformJSON(in.path)
+	if errors.Is(err, parser.ErrBinaryPlan) {
+		parseResult, err = parser.ParseBinaryPlan(in.path, terraformBin, in.binaryPlanDir())
+	}
+	if err != nil {
+		return nil, err
+	}
+	in.renameAll(parseResult.Resources)
+	return parseResult, nil
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// setStdin replaces os.Stdin with a file containing content for the duration of the test
+func setStdin(t *testing.T, content string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write stdin file: %v", err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open stdin file: %v", err)
+	}
+	oldStdin := os.Stdin
+	os.Stdin = file
+	t.Cleanup(func() {
+		os.Stdin = oldStdin
+		file.Close()
+	})
+}
+
+func TestPrepareInputs(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		displayName string
+		wantNames   []string
+		wantStdin   []bool
+		wantErr     bool
+	}{
+		{
+			name:      "file paths",
+			args:      []string{"a.json", "b.json"},
+			wantNames: []string{"a.json", "b.json"},
+			wantStdin: []bool{false, false},
+		},
+		{
+			name:      "stdin",
+			args:      []string{"a.json", "-"},
+			wantNames: []string{"a.json", "stdin"},
+			wantStdin: []bool{false, true},
+		},
+		{
+			name:        "display name",
+			args:        []string{"-"},
+			displayName: "prod.tfplan",
+			wantNames:   []string{"prod.tfplan"},
+			wantStdin:   []bool{true},
+		},
+		{
+			name:        "display name with several inputs",
+			args:        []string{"a.json", "b.json"},
+			displayName: "prod.tfplan",
+			wantErr:     true,
+		},
+		{
+			name:    "stdin used twice",
+			args:    []string{"-", "-"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setStdin(t, `{"planned_values": {}}`)
+
+			inputs, cleanup, err := prepareInputs(tt.args, tt.displayName)
+			defer cleanup()
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("prepareInputs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(inputs) != len(tt.wantNames) {
+				t.Fatalf("Expected %d inputs, got %d", len(tt.wantNames), len(inputs))
+			}
+			for i, in := range inputs {
+				if in.name != tt.wantNames[i] || in.stdin != tt.wantStdin[i] {
+					t.Errorf("Input %d: expected name %s (stdin %v), got %s (stdin %v)", i, tt.wantNames[i], tt.wantStdin[i], in.name, in.stdin)
+				}
+				if in.stdin {
+					data, err := os.ReadFile(in.path)
+					if err != nil || string(data) != `{"planned_values": {}}` {
+						t.Errorf("Expected stdin copy at %s, got %q (%v)", in.path, data, err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRunValidate_Stdin(t *testing.T) {
+	tests := []struct {
+		name       string
+		stdin      string
+		setup      func()
+		wantOutput []string
+	}{
+		{
+			name:  "plan from stdin with display name",
+			stdin: `{"planned_values": {"root_module": {"resources": [{"address": "aws_instance.web", "values": {"tags": {}}}]}}}`,
+			setup: func() {
+				planFiles = []string{"-"}
+				planName = "prod.tfplan"
+			},
+			wantOutput: []string{"📄 prod.tfplan", "Missing required tag: Owner"},
+		},
+		{
+			name:  "state from stdin",
+			stdin: `{"version": 4, "resources": [{"mode": "managed", "type": "aws_instance", "name": "db", "instances": [{"attributes": {"tags": {}}}]}]}`,
+			setup: func() {
+				stateFile = "-"
+			},
+			wantOutput: []string{"📄 stdin", "aws_instance.db"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configPath, []byte("global:\n  always_required_tags: [Owner]\n"), 0644); err != nil {
+				t.Fatalf("Failed to write config file: %v", err)
+			}
+			configFiles = []string{configPath}
+			tt.setup()
+			defer func() {
+				configFiles = nil
+				planFiles = nil
+				planName = ""
+				stateFile = ""
+			}()
+			setStdin(t, tt.stdin)
+
+			output, err := captureOutput(t, func() error {
+				return runValidate(&cobra.Command{}, nil)
+			})
+			if err == nil {
+				t.Error("Expected violations error")
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, but it doesn't.\nOutput:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
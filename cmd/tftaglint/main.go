@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/tom-023/tftaglint/internal/config"
@@ -12,9 +13,19 @@ import (
 )
 
 var (
-	configFile  string
+	configFiles []string
 	showSummary bool
-	planFile    string
+	planFiles   []string
+	stateFile   string
+	driftMode   bool
+	changesMode bool
+	planName    string
+	stateName   string
+	failOn      string
+	nowFlag     string
+
+	terraformBin string
+	terraformDir string
 )
 
 var rootCmd = &cobra.Command{
@@ -32,10 +43,21 @@ var validateCmd = &cobra.Command{
 }
 
 func init() {
-	validateCmd.Flags().StringVarP(&configFile, "config", "c", "tag-rules.yaml", "Path to the configuration file")
-	validateCmd.Flags().StringVarP(&configFile, "file", "f", "tag-rules.yaml", "Path to the configuration file (alias for --config)")
+	validateCmd.Flags().StringArrayVarP(&configFiles, "config", "c", nil, "Path to the configuration file (repeatable, later files are layered over earlier ones; default: the tag-rules.yaml or .tftaglint.yaml files from each directory up to the repository root)")
+	validateCmd.Flags().VarP(validateCmd.Flags().Lookup("config").Value, "file", "f", "Path to the configuration file (alias for --config)")
 	validateCmd.Flags().BoolVarP(&showSummary, "summary", "s", false, "Show summary of violations")
-	validateCmd.Flags().StringVarP(&planFile, "plan", "p", "", "Path to terraform plan JSON file (use instead of .tf files)")
+	validateCmd.Flags().StringArrayVarP(&planFiles, "plan", "p", nil, "Path or glob of terraform plan files, or - for stdin (repeatable, use instead of .tf files)")
+	validateCmd.Flags().StringVar(&planName, "plan-name", "", "Name shown for the plan in reports instead of its path")
+	validateCmd.Flags().StringVar(&stateFile, "state", "", "Path to terraform state file or state JSON, or - for stdin (use instead of .tf files)")
+	validateCmd.Flags().StringVar(&stateName, "state-name", "", "Name shown for the state in reports instead of its path")
+	validateCmd.Flags().StringVar(&terraformBin, "terraform-bin", "terraform", "Terraform or OpenTofu binary used to convert binary plan files to JSON")
+	validateCmd.Flags().StringVar(&terraformDir, "terraform-dir", "", "Working directory for converting binary plan files (default: directory of the plan file)")
+	validateCmd.Flags().BoolVar(&driftMode, "drift", false, "Report tags changed outside Terraform (from the plan's resource_drift section)")
+	validateCmd.Flags().BoolVar(&changesMode, "tag-changes", false, "Report tags removed or changed by the plan and enforce protected_tags")
+	validateCmd.MarkFlagsMutuallyExclusive("plan", "state")
+	validateCmd.Flags().StringVar(&failOn, "fail-on", "error", "Minimum severity that makes the command exit non-zero (error, warning or info)")
+	validateCmd.Flags().StringVar(&nowFlag, "now", "", "Reference date for expiry checks, as YYYY-MM-DD or RFC 3339 (default: current time)")
+	validateCmd.MarkFlagsMutuallyExclusive("drift", "tag-changes")
 	rootCmd.AddCommand(validateCmd)
 }
 
@@ -47,21 +69,87 @@ func main() {
 }
 
 func runValidate(cmd *cobra.Command, args []string) error {
-	// Load configuration
-	cfg, err := config.LoadConfig(configFile)
+	// Validate resources against the reference time given by --now
+	var now time.Time
+	if nowFlag != "" {
+		var err error
+		if now, err = parseNow(nowFlag); err != nil {
+			return err
+		}
+	}
+	validators := newValidatorSet(now)
+
+	// Configs given with -c apply everywhere, so problems in them are reported first
+	if len(configFiles) > 0 {
+		if _, err := validators.forDir("."); err != nil {
+			return err
+		}
+	}
+
+	minSeverity, err := config.ParseSeverity(failOn)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return fmt.Errorf("invalid --fail-on: %w", err)
 	}
 
 	var parseResult *parser.ParseResult
 
-	// Check if plan file is provided
-	if planFile != "" {
-		// Parse terraform plan JSON
-		parseResult, err = parser.ParseTerraformPlan(planFile)
+	if driftMode && len(planFiles) == 0 {
+		return fmt.Errorf("--drift requires --plan")
+	}
+	if changesMode && len(planFiles) == 0 {
+		return fmt.Errorf("--tag-changes requires --plan")
+	}
+
+	// Plans, states and Terraform files are validated with the configs for their
+	// own directory; input read from stdin uses those for the working directory
+	var v *validator.Validator
+
+	// Check if plan or state file is provided
+	if len(planFiles) > 0 {
+		paths, err := expandPlanFiles(planFiles)
+		if err != nil {
+			return err
+		}
+		plans, cleanup, err := prepareInputs(paths, planName)
+		defer cleanup()
+		if err != nil {
+			return err
+		}
+		planValidators, err := validators.forInputs(plans)
+		if err != nil {
+			return err
+		}
+		if driftMode {
+			return runDrift(planValidators, plans, minSeverity)
+		}
+		if changesMode {
+			return runTagChanges(planValidators, plans, minSeverity)
+		}
+		if len(plans) > 1 {
+			return runValidatePlans(planValidators, plans, minSeverity)
+		}
+
+		v = planValidators[0]
+		parseResult, err = parsePlanFile(plans[0])
 		if err != nil {
 			return fmt.Errorf("failed to parse terraform plan: %w", err)
 		}
+	} else if stateFile != "" {
+		// Parse terraform state (raw tfstate or `terraform show -json` output)
+		states, cleanup, err := prepareInputs([]string{stateFile}, stateName)
+		defer cleanup()
+		if err != nil {
+			return err
+		}
+		stateValidators, err := validators.forInputs(states)
+		if err != nil {
+			return err
+		}
+		v = stateValidators[0]
+		parseResult, err = parseStateFile(states[0])
+		if err != nil {
+			return fmt.Errorf("failed to parse terraform state: %w", err)
+		}
 	} else {
 		// Default to current directory if no paths specified
 		paths := args
@@ -77,17 +165,15 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Report parsing errors if any
-	if len(parseResult.Errors) > 0 {
-		fmt.Fprintln(os.Stderr, "⚠️  Parsing errors encountered:")
-		for _, err := range parseResult.Errors {
-			fmt.Fprintf(os.Stderr, "  - %v\n", err)
-		}
-		fmt.Fprintln(os.Stderr)
-	}
+	reportParseErrors(parseResult.Errors)
 
 	// Validate resources
-	v := validator.NewValidator(cfg)
-	violations := v.Validate(parseResult.Resources)
+	var violations []validator.Violation
+	if v != nil {
+		violations = v.Validate(parseResult.Resources)
+	} else if violations, err = validators.validate(parseResult.Resources); err != nil {
+		return err
+	}
 
 	// Report violations
 	r := reporter.NewReporter(os.Stdout)
@@ -102,11 +188,45 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// Exit with non-zero status if violations found
-	if len(violations) > 0 {
+	// Exit with non-zero status if violations at or above --fail-on were found
+	if failing := countFailing(violations, minSeverity); failing > 0 {
 		// Return error to allow cobra to handle exit
-		return fmt.Errorf("found %d tag violations", len(violations))
+		return fmt.Errorf("found %d tag violations at or above severity %s", failing, minSeverity)
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// parseNow parses the --now flag as a date or an RFC 3339 timestamp
+func parseNow(value string) (time.Time, error) {
+	if now, err := time.Parse("2006-01-02", value); err == nil {
+		return now, nil
+	}
+	now, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --now %q: use YYYY-MM-DD or RFC 3339", value)
+	}
+	return now, nil
+}
+
+// countFailing returns the number of violations at or above the minimum severity
+func countFailing(violations []validator.Violation, minSeverity config.Severity) int {
+	count := 0
+	for _, v := range violations {
+		if v.Severity.AtLeast(minSeverity) {
+			count++
+		}
+	}
+	return count
+}
+
+func reportParseErrors(errs []error) {
+	if len(errs) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "⚠️  Parsing errors encountered:")
+	for _, err := range errs {
+		fmt.Fprintf(os.Stderr, "  - %v\n", err)
+	}
+	fmt.Fprintln(os.Stderr)
+}
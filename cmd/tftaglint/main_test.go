@@ -17,17 +17,19 @@ func TestRunValidate(t *testing.T) {
 	defer func() { os.Stdout = oldStdout }()
 
 	tests := []struct {
-		name         string
-		configFile   string
+		name          string
+		configFile    string
 		configContent string
-		tfFiles      map[string]string
-		planFile     string
-		planContent  string
-		args         []string
-		wantOutput   []string
-		wantErr      bool
-		setupFunc    func() error
-		cleanupFunc  func()
+		tfFiles       map[string]string
+		planFile      string
+		planContent   string
+		planContents  map[string]string
+		stateContent  string
+		args          []string
+		wantOutput    []string
+		wantErr       bool
+		setupFunc     func() error
+		cleanupFunc   func()
 	}{
 		{
 			name: "valid terraform files with no violations",
@@ -55,6 +57,33 @@ resource "aws_instance" "web" {
 			wantOutput: []string{"✅ No tag violations found!"},
 			wantErr:    false,
 		},
+		{
+			name: "data sources skipped by global required tags",
+			configContent: `
+global:
+  always_required_tags:
+    - Owner`,
+			tfFiles: map[string]string{
+				"main.tf": `
+data "aws_ami" "ubuntu" {}`,
+			},
+			wantOutput: []string{"✅ No tag violations found!"},
+			wantErr:    false,
+		},
+		{
+			name: "data sources checked by rules selecting them",
+			configContent: `
+rules:
+  - name: ami-owner
+    resource_types: ["data.aws_ami"]
+    required_tags: [Owner]`,
+			tfFiles: map[string]string{
+				"main.tf": `
+data "aws_ami" "ubuntu" {}`,
+			},
+			wantOutput: []string{"Missing required tag: Owner"},
+			wantErr:    true,
+		},
 		{
 			name: "terraform files with violations",
 			configContent: `
@@ -106,7 +135,92 @@ global:
 			wantErr:    false,
 		},
 		{
-			name: "invalid config file",
+			name: "gcp provider limits checked on plan labels",
+			configContent: `
+global:
+  provider_limits: [gcp]`,
+			planContent: `{
+  "planned_values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "google_storage_bucket.assets",
+          "type": "google_storage_bucket",
+          "name": "assets",
+          "values": {
+            "labels": {
+              "Owner": "team-a"
+            }
+          }
+        }
+      ]
+    }
+  }
+}`,
+			wantOutput: []string{
+				"google_storage_bucket.assets",
+				"Tag key 'Owner' is not valid for",
+			},
+			wantErr: true,
+		},
+		{
+			name: "state file validation",
+			configContent: `
+global:
+  always_required_tags:
+    - Owner`,
+			stateContent: `{
+  "version": 4,
+  "resources": [
+    {
+      "module": "module.app",
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "instances": [
+        {"index_key": 0, "attributes": {"tags": {"Name": "web-server"}}}
+      ]
+    }
+  ]
+}`,
+			wantOutput: []string{
+				"module.app.aws_instance.web[0]",
+				"Missing required tag: Owner",
+			},
+			wantErr: true,
+		},
+		{
+			name: "state resource with dotted for_each key",
+			configContent: `
+rules:
+  - name: namespace-owner
+    resource_types: ["kubernetes_*"]
+    required_tags: [Owner]`,
+			stateContent: `{
+  "format_version": "1.0",
+  "values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "kubernetes_namespace.ns[\"a.b\"]",
+          "mode": "managed",
+          "type": "kubernetes_namespace",
+          "name": "ns",
+          "index": "a.b",
+          "values": {}
+        }
+      ]
+    }
+  }
+}`,
+			wantOutput: []string{
+				`kubernetes_namespace.ns["a.b"]`,
+				"Missing required tag: Owner",
+			},
+			wantErr: true,
+		},
+		{
+			name:          "invalid config file",
 			configContent: `invalid yaml content: [`,
 			tfFiles: map[string]string{
 				"main.tf": `resource "aws_instance" "web" {}`,
@@ -114,7 +228,7 @@ global:
 			wantErr: true,
 		},
 		{
-			name: "parsing errors in terraform files",
+			name:          "parsing errors in terraform files",
 			configContent: `rules: []`,
 			tfFiles: map[string]string{
 				"invalid.tf": `resource "aws_instance" {
@@ -155,26 +269,170 @@ resource "aws_instance" "db" {
 			},
 			wantErr: true, // violations found
 		},
+		{
+			name: "warnings do not fail by default",
+			configContent: `
+rules:
+  - name: owner
+    required_tags: [Owner]
+    severity: warning`,
+			tfFiles: map[string]string{
+				"main.tf": `
+resource "aws_instance" "web" {
+  tags = {}
+}`,
+			},
+			wantOutput: []string{
+				"❌ Found 1 tag violation(s):",
+				"Severity: warning",
+			},
+			wantErr: false,
+		},
+		{
+			name: "warnings fail with --fail-on warning",
+			configContent: `
+rules:
+  - name: owner
+    required_tags: [Owner]
+    severity: warning`,
+			tfFiles: map[string]string{
+				"main.tf": `
+resource "aws_instance" "web" {
+  tags = {}
+}`,
+			},
+			setupFunc: func() error {
+				failOn = "warning"
+				return nil
+			},
+			cleanupFunc: func() {
+				failOn = "error"
+			},
+			wantOutput: []string{"Severity: warning"},
+			wantErr:    true,
+		},
+		{
+			name:          "invalid --fail-on",
+			configContent: `rules: []`,
+			setupFunc: func() error {
+				failOn = "critical"
+				return nil
+			},
+			cleanupFunc: func() {
+				failOn = "error"
+			},
+			wantErr: true,
+		},
+		{
+			name: "expiry checked against --now",
+			configContent: `
+rules:
+  - name: sandbox-expiry
+    tag_constraints:
+      - tag: ExpiresOn
+        type: expiry
+        max_days_ahead: 30`,
+			tfFiles: map[string]string{
+				"main.tf": `
+resource "aws_instance" "expired" {
+  tags = {
+    ExpiresOn = "2024-06-01"
+  }
+}
+resource "aws_instance" "valid" {
+  tags = {
+    ExpiresOn = "2024-06-30"
+  }
+}`,
+			},
+			setupFunc: func() error {
+				nowFlag = "2024-06-15"
+				return nil
+			},
+			cleanupFunc: func() {
+				nowFlag = ""
+			},
+			wantOutput: []string{
+				"❌ Found 1 tag violation(s):",
+				"Invalid value for tag ExpiresOn: '2024-06-01' expired 14 day(s) ago",
+			},
+			wantErr: true,
+		},
+		{
+			name:          "invalid --now",
+			configContent: `rules: []`,
+			setupFunc: func() error {
+				nowFlag = "tomorrow"
+				return nil
+			},
+			cleanupFunc: func() {
+				nowFlag = ""
+			},
+			wantErr: true,
+		},
 		{
 			name: "config file not found",
 			setupFunc: func() error {
-				configFile = "/non/existent/config.yaml"
+				configFiles = []string{"/non/existent/config.yaml"}
+				return nil
+			},
+			cleanupFunc: func() {
+				configFiles = nil
+			},
+			wantErr: true,
+		},
+		{
+			name:          "plan file not found",
+			configContent: `rules: []`,
+			setupFunc: func() error {
+				planFiles = []string{"/non/existent/plan.json"}
+				return nil
+			},
+			cleanupFunc: func() {
+				planFiles = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "multiple plan files grouped per plan",
+			configContent: `
+global:
+  always_required_tags:
+    - Name`,
+			planContents: map[string]string{
+				"dev.json": `{"planned_values": {"root_module": {"resources": [
+  {"address": "aws_instance.web", "values": {"tags": {"Name": "web"}}}
+]}}}`,
+				"prod.json": `{"planned_values": {"root_module": {"resources": [
+  {"address": "aws_instance.web", "values": {"tags": {}}},
+  {"address": "aws_instance.db", "values": {"tags": {}}}
+]}}}`,
+			},
+			setupFunc: func() error {
+				showSummary = true
 				return nil
 			},
 			cleanupFunc: func() {
-				configFile = "tag-rules.yaml"
+				showSummary = false
+			},
+			wantOutput: []string{
+				"❌ Found 2 tag violation(s) across 2 plan(s):",
+				"dev.json: ✅ no violations",
+				"prod.json: 2 violation(s)",
+				"Violations by plan:",
+				"global-required-tags: 2",
 			},
 			wantErr: true,
 		},
 		{
-			name: "plan file not found",
+			name:          "plan glob without matches",
 			configContent: `rules: []`,
 			setupFunc: func() error {
-				planFile = "/non/existent/plan.json"
+				planFiles = []string{"/non/existent/*.json"}
 				return nil
 			},
 			cleanupFunc: func() {
-				planFile = ""
+				planFiles = nil
 			},
 			wantErr: true,
 		},
@@ -203,8 +461,8 @@ resource "aws_instance" "db" {
 				if err := os.WriteFile(configPath, []byte(tt.configContent), 0644); err != nil {
 					t.Fatalf("Failed to write config file: %v", err)
 				}
-				configFile = configPath
-				defer func() { configFile = "tag-rules.yaml" }()
+				configFiles = []string{configPath}
+				defer func() { configFiles = nil }()
 			}
 			
 			// Create terraform files
@@ -221,8 +479,33 @@ resource "aws_instance" "db" {
 				if err := os.WriteFile(planPath, []byte(tt.planContent), 0644); err != nil {
 					t.Fatalf("Failed to write plan file: %v", err)
 				}
-				planFile = planPath
-				defer func() { planFile = "" }()
+				planFiles = []string{planPath}
+				defer func() { planFiles = nil }()
+			}
+			
+			// Create several plan files matched by a glob
+			if len(tt.planContents) > 0 {
+				planDir := filepath.Join(tmpDir, "plans")
+				if err := os.Mkdir(planDir, 0755); err != nil {
+					t.Fatalf("Failed to create plan directory: %v", err)
+				}
+				for name, content := range tt.planContents {
+					if err := os.WriteFile(filepath.Join(planDir, name), []byte(content), 0644); err != nil {
+						t.Fatalf("Failed to write plan file: %v", err)
+					}
+				}
+				planFiles = []string{filepath.Join(planDir, "*.json")}
+				defer func() { planFiles = nil }()
+			}
+			
+			// Create state file
+			if tt.stateContent != "" {
+				statePath := filepath.Join(tmpDir, "terraform.tfstate")
+				if err := os.WriteFile(statePath, []byte(tt.stateContent), 0644); err != nil {
+					t.Fatalf("Failed to write state file: %v", err)
+				}
+				stateFile = statePath
+				defer func() { stateFile = "" }()
 			}
 			
 			// Capture stdout and stderr
@@ -321,8 +604,8 @@ func TestInit(t *testing.T) {
 	if configFlag == nil {
 		t.Error("config flag not found")
 	}
-	if configFlag.DefValue != "tag-rules.yaml" {
-		t.Errorf("Expected default config file 'tag-rules.yaml', got %s", configFlag.DefValue)
+	if configFlag.DefValue != "[]" {
+		t.Errorf("Expected no default config file, got %s", configFlag.DefValue)
 	}
 	
 	fileFlag := validateCmd.Flags().Lookup("file")
@@ -339,4 +622,23 @@ func TestInit(t *testing.T) {
 	if planFlag == nil {
 		t.Error("plan flag not found")
 	}
+	
+	stateFlag := validateCmd.Flags().Lookup("state")
+	if stateFlag == nil {
+		t.Error("state flag not found")
+	}
+	
+	terraformBinFlag := validateCmd.Flags().Lookup("terraform-bin")
+	if terraformBinFlag == nil {
+		t.Error("terraform-bin flag not found")
+	} else if terraformBinFlag.DefValue != "terraform" {
+		t.Errorf("Expected default terraform-bin 'terraform', got %s", terraformBinFlag.DefValue)
+	}
+	
+	failOnFlag := validateCmd.Flags().Lookup("fail-on")
+	if failOnFlag == nil {
+		t.Error("fail-on flag not found")
+	} else if failOnFlag.DefValue != "error" {
+		t.Errorf("Expected default fail-on 'error', got %s", failOnFlag.DefValue)
+	}
 }
\ No newline at end of file
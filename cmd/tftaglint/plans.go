@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/tom-023/tftaglint/internal/config"
+	"github.com/tom-023/tftaglint/internal/reporter"
+	"github.com/tom-023/tftaglint/internal/validator"
+)
+
+// expandPlanFiles resolves the --plan values into a list of plan files.
+// Values containing glob characters are expanded and must match at least one
+// file; plain paths are kept as-is so that missing files are reported when parsed.
+func expandPlanFiles(patterns []string) ([]string, error) {
+	var plans []string
+	seen := make(map[string]bool)
+
+	for _, pattern := range patterns {
+		matches := []string{pattern}
+		if hasGlobMeta(pattern) {
+			var err error
+			matches, err = filepath.Glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid plan pattern %q: %w", pattern, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no plan files match %q", pattern)
+			}
+		}
+
+		for _, match := range matches {
+			if !seen[match] {
+				seen[match] = true
+				plans = append(plans, match)
+			}
+		}
+	}
+
+	return plans, nil
+}
+
+func hasGlobMeta(pattern string) bool {
+	for _, c := range pattern {
+		switch c {
+		case '*', '?', '[':
+			return true
+		}
+	}
+	return false
+}
+
+// planResult holds the outcome of parsing and validating a single plan
+type planResult struct {
+	violations  []validator.Violation
+	parseErrors []error
+	err         error
+}
+
+// runValidatePlans parses and validates several plans concurrently, each with
+// the validator at its index, and reports the violations grouped per plan
+func runValidatePlans(validators []*validator.Validator, plans []input, minSeverity config.Severity) error {
+	results := make([]planResult, len(plans))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, runtime.NumCPU())
+	for i, plan := range plans {
+		wg.Add(1)
+		go func(i int, plan input) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			parseResult, err := parsePlanFile(plan)
+			if err != nil {
+				results[i].err = fmt.Errorf("failed to parse terraform plan %s: %w", plan.name, err)
+				return
+			}
+			for j := range parseResult.Resources {
+				parseResult.Resources[j].Workspace = plan.name
+			}
+			results[i].parseErrors = parseResult.Errors
+			results[i].violations = validators[i].Validate(parseResult.Resources)
+		}(i, plan)
+	}
+	wg.Wait()
+
+	var violations []validator.Violation
+	var parseErrors []error
+	for i, result := range results {
+		if result.err != nil {
+			return result.err
+		}
+		for _, err := range result.parseErrors {
+			parseErrors = append(parseErrors, fmt.Errorf("%s: %w", plans[i].name, err))
+		}
+		violations = append(violations, result.violations...)
+	}
+
+	reportParseErrors(parseErrors)
+
+	workspaces := make([]string, len(plans))
+	for i, plan := range plans {
+		workspaces[i] = plan.name
+	}
+
+	r := reporter.NewReporter(os.Stdout)
+	if err := r.ReportWorkspaces(workspaces, violations); err != nil {
+		return fmt.Errorf("failed to report violations: %w", err)
+	}
+
+	if showSummary && len(violations) > 0 {
+		if err := r.ReportWorkspacesSummary(workspaces, violations); err != nil {
+			return fmt.Errorf("failed to report summary: %w", err)
+		}
+	}
+
+	if failing := countFailing(violations, minSeverity); failing > 0 {
+		return fmt.Errorf("found %d tag violations at or above severity %s across %d plans", failing, minSeverity, len(plans))
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExpandPlanFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"dev.json", "prod.json", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte("{}"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "plain path kept as-is",
+			patterns: []string{"missing.json"},
+			want:     []string{"missing.json"},
+		},
+		{
+			name:     "glob expanded in sorted order",
+			patterns: []string{filepath.Join(tmpDir, "*.json")},
+			want:     []string{filepath.Join(tmpDir, "dev.json"), filepath.Join(tmpDir, "prod.json")},
+		},
+		{
+			name:     "duplicates removed",
+			patterns: []string{filepath.Join(tmpDir, "prod.json"), filepath.Join(tmpDir, "*.json")},
+			want:     []string{filepath.Join(tmpDir, "prod.json"), filepath.Join(tmpDir, "dev.json")},
+		},
+		{
+			name:     "glob without matches",
+			patterns: []string{filepath.Join(tmpDir, "*.tfplan")},
+			wantErr:  true,
+		},
+		{
+			name:     "malformed glob",
+			patterns: []string{"plans/[.json"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandPlanFiles(tt.patterns)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandPlanFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandPlanFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tom-023/tftaglint/internal/config"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print a JSON Schema for the configuration file",
+	Long: `Print a JSON Schema (draft-07) for tag-rules.yaml and JSON configuration files, for validation and completion in editors.
+For example, with the VS Code YAML extension, save it with "tftaglint schema > tag-rules.schema.json" and add "# yaml-language-server: $schema=./tag-rules.schema.json" to the top of tag-rules.yaml.`,
+	Args: cobra.NoArgs,
+	RunE: runSchema,
+}
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(config.Schema()); err != nil {
+		return fmt.Errorf("failed to print schema: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRunSchema(t *testing.T) {
+	output, err := captureOutput(t, func() error {
+		return runSchema(schemaCmd, nil)
+	})
+	if err != nil {
+		t.Fatalf("runSchema() error = %v", err)
+	}
+
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &schema); err != nil {
+		t.Fatalf("Expected JSON output, got error %v.\nOutput:\n%s", err, output)
+	}
+	if schema["$schema"] != "http://json-schema.org/draft-07/schema#" {
+		t.Errorf("Expected draft-07 $schema, got %v", schema["$schema"])
+	}
+	definitions, _ := schema["definitions"].(map[string]interface{})
+	if _, ok := definitions["Rule"]; !ok {
+		t.Errorf("Expected a Rule definition.\nOutput:\n%s", output)
+	}
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"regexp"
+	"strings"
+)
+
+// CaseStyle is a naming convention a tag value must follow
+type CaseStyle string
+
+const (
+	CaseLower  CaseStyle = "lowercase"
+	CaseUpper  CaseStyle = "uppercase"
+	CaseKebab  CaseStyle = "kebab-case"
+	CaseSnake  CaseStyle = "snake_case"
+	CasePascal CaseStyle = "PascalCase"
+	CaseCamel  CaseStyle = "camelCase"
+)
+
+var caseStylePatterns = map[CaseStyle]*regexp.Regexp{
+	CaseKebab:  regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`),
+	CaseSnake:  regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`),
+	CasePascal: regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`),
+	CaseCamel:  regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`),
+}
+
+// caseStyleNames lists the supported styles for error messages
+const caseStyleNames = "lowercase, uppercase, kebab-case, snake_case, PascalCase or camelCase"
+
+// IsValid reports whether c is a known case style; the empty style is valid
+// and means no case check
+func (c CaseStyle) IsValid() bool {
+	switch c {
+	case "", CaseLower, CaseUpper:
+		return true
+	}
+	_, ok := caseStylePatterns[c]
+	return ok
+}
+
+// Match reports whether value follows the case style
+func (c CaseStyle) Match(value string) bool {
+	switch c {
+	case "":
+		return true
+	case CaseLower:
+		return value == strings.ToLower(value)
+	case CaseUpper:
+		return value == strings.ToUpper(value)
+	}
+	pattern, ok := caseStylePatterns[c]
+	return ok && pattern.MatchString(value)
+}
@@ -0,0 +1,32 @@
+package config
+
+import "testing"
+
+func TestCaseStyle_Match(t *testing.T) {
+	tests := []struct {
+		style CaseStyle
+		value string
+		want  bool
+	}{
+		{CaseLower, "platform-core", true},
+		{CaseLower, "Platform", false},
+		{CaseUpper, "PROD", true},
+		{CaseUpper, "Prod", false},
+		{CaseKebab, "platform-core-2", true},
+		{CaseKebab, "platform--core", false},
+		{CaseKebab, "platform_core", false},
+		{CaseSnake, "platform_core", true},
+		{CaseSnake, "platform-core", false},
+		{CasePascal, "PlatformCore", true},
+		{CasePascal, "platformCore", false},
+		{CaseCamel, "platformCore", true},
+		{CaseCamel, "PlatformCore", false},
+		{"", "Anything Goes", true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.style.Match(tt.value); got != tt.want {
+			t.Errorf("%s.Match(%q) = %v, want %v", tt.style, tt.value, got, tt.want)
+		}
+	}
+}
@@ -3,73 +3,221 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	Rules  []Rule `yaml:"rules"`
-	Global Global `yaml:"global"`
+	Extends   []string            `yaml:"extends,omitempty"`    // Configs this one is layered over, relative to this file
+	ValueSets map[string][]string `yaml:"value_sets,omitempty"` // Named allowed_values, referenced as $name
+	TagGroups map[string][]string `yaml:"tag_groups,omitempty"` // Named lists of tag keys, referenced as $name
+	Rules     []Rule              `yaml:"rules,omitempty"`
+	Global    Global              `yaml:"global,omitempty"`
 }
 
 type Rule struct {
-	Name             string           `yaml:"name"`
-	Description      string           `yaml:"description"`
-	RequiredTags     []string         `yaml:"required_tags"`
-	ForbiddenTags    []string         `yaml:"forbidden_tags"`
-	Condition        *Condition       `yaml:"condition"`
-	ResourceTypes    []string         `yaml:"resource_types"`
-	TagConstraints   []TagConstraint  `yaml:"tag_constraints"`
-	TagPatterns      []TagPattern     `yaml:"tag_patterns"`
+	Name                 string          `yaml:"name,omitempty"`
+	Description          string          `yaml:"description,omitempty"`
+	RequiredTags         []string        `yaml:"required_tags,omitempty"`
+	ForbiddenTags        []string        `yaml:"forbidden_tags,omitempty"`
+	RequiredAnyOf        [][]string      `yaml:"required_any_of,omitempty"`    // Groups of tags of which at least one is required
+	RequiredOneOf        [][]string      `yaml:"required_one_of,omitempty"`    // Groups of tags of which exactly one is required
+	MutuallyExclusive    [][]string      `yaml:"mutually_exclusive,omitempty"` // Groups of tags of which at most one may be set
+	Dependencies         []Dependency    `yaml:"dependencies,omitempty"`
+	Condition            *Condition      `yaml:"condition,omitempty"`
+	ResourceTypes        []string        `yaml:"resource_types,omitempty"`
+	ExcludeResourceTypes []string        `yaml:"exclude_resource_types,omitempty"`
+	TagConstraints       []TagConstraint `yaml:"tag_constraints,omitempty"`
+	TagPatterns          []TagPattern    `yaml:"tag_patterns,omitempty"`
+	ProtectedTags        []string        `yaml:"protected_tags,omitempty"`
+	Lookup               *Lookup         `yaml:"lookup,omitempty"`
+	Severity             Severity        `yaml:"severity,omitempty"`
+	Disabled             bool            `yaml:"disabled,omitempty"` // Removes the rule of the same name from earlier configs
+
+	origin ValidationError // Where the rule is defined, for errors found when merging
 }
 
+// Condition selects the resources a rule applies to. A condition is either a
+// combinator (all, any or not) or a test on a single tag using one operator;
+// without an operator the tag must equal value.
 type Condition struct {
-	Tag   string `yaml:"tag"`
-	Value string `yaml:"value"`
+	All []Condition `yaml:"all,omitempty"`
+	Any []Condition `yaml:"any,omitempty"`
+	Not *Condition  `yaml:"not,omitempty"`
+
+	Tag       string   `yaml:"tag,omitempty"`
+	Value     string   `yaml:"value,omitempty"`
+	In        []string `yaml:"in,omitempty"`
+	NotIn     []string `yaml:"not_in,omitempty"` // Also true when the tag is absent
+	Matches   string   `yaml:"matches,omitempty"`
+	Prefix    string   `yaml:"prefix,omitempty"`
+	Exists    bool     `yaml:"exists,omitempty"`
+	NotExists bool     `yaml:"not_exists,omitempty"`
+
+	MatchesRegex *regexp.Regexp `yaml:"-"`
+}
+
+// Dependency requires tags depending on the presence of another tag. Exactly
+// one of WhenPresent and WhenAbsent is set; Value narrows WhenPresent to a value.
+type Dependency struct {
+	WhenPresent string   `yaml:"when_present,omitempty"`
+	WhenAbsent  string   `yaml:"when_absent,omitempty"`
+	Value       string   `yaml:"value,omitempty"`
+	Require     []string `yaml:"require,omitempty"`
 }
 
+// TagConstraint restricts the value of a tag. Every check that is set must pass.
 type TagConstraint struct {
-	Tag           string   `yaml:"tag"`
-	AllowedValues []string `yaml:"allowed_values"`
+	Tag               string      `yaml:"tag,omitempty"`
+	AllowedValues     []string    `yaml:"allowed_values,omitempty"`
+	AllowedValuesFile *ValuesFile `yaml:"allowed_values_file,omitempty"` // Combined with allowed_values
+	Pattern           string      `yaml:"pattern,omitempty"`             // Regex the value must match
+	NotPattern        string      `yaml:"not_pattern,omitempty"`         // Regex the value must not match
+	MinLength         int         `yaml:"min_length,omitempty"`
+	MaxLength         int         `yaml:"max_length,omitempty"`
+	Case              CaseStyle   `yaml:"case,omitempty"`
+
+	// Type checks the value is of a given kind; the options below apply to
+	// specific types only
+	Type    ValueType `yaml:"type,omitempty"`
+	Min     *int64    `yaml:"min,omitempty"`     // integer
+	Max     *int64    `yaml:"max,omitempty"`     // integer
+	Domains []string  `yaml:"domains,omitempty"` // email
+	Schemes []string  `yaml:"schemes,omitempty"` // url
+
+	Formats      []string `yaml:"formats,omitempty"`        // date, expiry; defaults to YYYY-MM-DD
+	MaxDaysAhead *int     `yaml:"max_days_ahead,omitempty"` // date, expiry
+
+	PatternRegex    *regexp.Regexp `yaml:"-"`
+	NotPatternRegex *regexp.Regexp `yaml:"-"`
 }
 
 type TagPattern struct {
-	Pattern string `yaml:"pattern"`
-	Message string `yaml:"message"`
+	Pattern string         `yaml:"pattern,omitempty"`
+	Message string         `yaml:"message,omitempty"`
 	Regex   *regexp.Regexp `yaml:"-"`
 }
 
 type Global struct {
-	AlwaysRequiredTags  []string `yaml:"always_required_tags"`
-	IgnoreResourceTypes []string `yaml:"ignore_resource_types"`
+	AlwaysRequiredTags  []string `yaml:"always_required_tags,omitempty"`
+	IgnoreResourceTypes []string `yaml:"ignore_resource_types,omitempty"`
+	ProviderLimits      []string `yaml:"provider_limits,omitempty"` // Provider tag limit profiles to enforce: aws, azure or gcp
+	Severity            Severity `yaml:"severity,omitempty"`        // Severity of always_required_tags and provider_limits violations
+	Replace             []string `yaml:"replace,omitempty"`         // Lists that replace those of earlier configs instead of being appended
 }
 
+// LoadConfig reads and validates a config file and the configs it extends,
+// and merges them into the effective config. Unknown keys and semantic
+// problems are reported together as ValidationErrors with their line and column.
 func LoadConfig(filename string) (*Config, error) {
+	return LoadConfigs([]string{filename})
+}
+
+// LoadConfigs loads several config files and merges them in order, so that
+// each file is layered over the ones before it and the configs they extend
+func LoadConfigs(filenames []string) (*Config, error) {
+	var layers []*layer
+	loaded := make(map[string]bool)
+	for _, filename := range filenames {
+		fileLayers, err := loadLayers(filename, make(map[string]bool), loaded)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, fileLayers...)
+	}
+
+	// References in each file can use the sets defined in it and before it
+	merged := &Config{}
+	for _, l := range layers {
+		valueSets := mergeSets(merged.ValueSets, l.config.ValueSets)
+		tagGroups := mergeSets(merged.TagGroups, l.config.TagGroups)
+		cv := &configValidator{file: l.file}
+		cv.expandReferences(l.config, l.root, valueSets, tagGroups)
+		if err := cv.err(); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if merged, err = merge(merged, l.config); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+// loadFile reads and validates a single config file without resolving extends
+// or references
+func loadFile(filename string) (*layer, error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var config Config
-	err = yaml.Unmarshal(data, &config)
+	root, err := parseConfigFile(filename, data)
 	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := root.Decode(&config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	cv := &configValidator{file: filename}
+	cv.checkKnownFields(root, reflect.TypeOf(config), "config")
+	cv.checkSets(&config, root)
+	cv.checkRules(&config, root)
+	cv.checkGlobal(&config, root)
+
 	// Compile regex patterns
+	ruleNodes := sequenceItems(mappingValue(documentContent(root), "rules"))
 	for i := range config.Rules {
+		patternNodes := []*yaml.Node{}
+		if i < len(ruleNodes) {
+			patternNodes = sequenceItems(mappingValue(ruleNodes[i], "tag_patterns"))
+		}
 		for j := range config.Rules[i].TagPatterns {
 			pattern := &config.Rules[i].TagPatterns[j]
 			regex, err := regexp.Compile(pattern.Pattern)
 			if err != nil {
-				return nil, fmt.Errorf("invalid regex pattern in rule %s: %w", config.Rules[i].Name, err)
+				node := &yaml.Node{}
+				if j < len(patternNodes) {
+					node = nodeOr(mappingValue(patternNodes[j], "pattern"), patternNodes[j])
+				}
+				cv.addError(node, "invalid regex pattern in rule %s: %v", config.Rules[i].Name, err)
+				continue
 			}
 			pattern.Regex = regex
 		}
 	}
 
-	return &config, nil
+	if err := cv.err(); err != nil {
+		return nil, err
+	}
+
+	return &layer{config: &config, file: filename, root: root}, nil
+}
+
+// parseConfigFile parses a config file into a YAML document node, with a
+// decoder chosen by extension: .json and .hcl files are converted, anything
+// else is read as YAML
+func parseConfigFile(filename string, data []byte) (*yaml.Node, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".json":
+		return parseJSON(filename, data)
+	case ".hcl":
+		return parseHCL(filename, data)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return &root, nil
 }
 
 func (tp *TagPattern) Validate(tagName string) bool {
@@ -77,4 +225,12 @@ func (tp *TagPattern) Validate(tagName string) bool {
 		return true
 	}
 	return tp.Regex.MatchString(tagName)
-}
\ No newline at end of file
+}
+
+// Trigger returns the tag whose presence or absence the dependency depends on
+func (d *Dependency) Trigger() string {
+	if d.WhenPresent != "" {
+		return d.WhenPresent
+	}
+	return d.WhenAbsent
+}
@@ -53,6 +53,8 @@ rules:
     condition:
       tag: Environment
       value: prod
+    protected_tags:
+      - CostCenter
 `,
 			wantErr: false,
 			check: func(t *testing.T, config *Config) {
@@ -86,6 +88,9 @@ rules:
 				
 				// Check second rule condition
 				rule2 := config.Rules[1]
+				if len(rule2.ProtectedTags) != 1 || rule2.ProtectedTags[0] != "CostCenter" {
+					t.Errorf("Expected protected tag CostCenter, got %v", rule2.ProtectedTags)
+				}
 				if rule2.Condition == nil {
 					t.Error("Expected condition to be set")
 				} else {
@@ -114,6 +119,38 @@ rules:
 				}
 			},
 		},
+		{
+			name: "combined condition",
+			content: `
+rules:
+  - name: backup
+    condition:
+      all:
+        - tag: Environment
+          in: [staging, production]
+        - tag: DataClass
+          exists: true
+        - not:
+            tag: Team
+            matches: "^sandbox-"
+    required_tags:
+      - BackupPolicy
+`,
+			wantErr: false,
+			check: func(t *testing.T, config *Config) {
+				condition := config.Rules[0].Condition
+				if condition == nil || len(condition.All) != 3 {
+					t.Fatalf("Expected all condition with 3 entries, got %+v", condition)
+				}
+				if len(condition.All[0].In) != 2 || !condition.All[1].Exists {
+					t.Errorf("Unexpected conditions: %+v", condition.All)
+				}
+				not := condition.All[2].Not
+				if not == nil || not.MatchesRegex == nil || !not.MatchesRegex.MatchString("sandbox-a") {
+					t.Errorf("Expected compiled matches pattern, got %+v", not)
+				}
+			},
+		},
 		{
 			name:    "invalid yaml",
 			content: `invalid: [yaml content`,
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileNames are the config file names FindConfigFiles looks for, in order of
+// preference when a directory has several
+var FileNames = []string{
+	"tag-rules.yaml", "tag-rules.json", "tag-rules.hcl",
+	".tftaglint.yaml", ".tftaglint.json", ".tftaglint.hcl",
+}
+
+// FindConfigFiles returns the config files that apply to dir: the config in
+// dir and in each of its parents up to the repository root (the nearest
+// directory containing .git), outermost first so that they can be passed to
+// LoadConfigs
+func FindConfigFiles(dir string) ([]string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve directory: %w", err)
+	}
+
+	start := dir
+	var files []string
+	for {
+		for _, name := range FileNames {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err == nil {
+				files = append([]string{path}, files...)
+				break
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("failed to look for config file: %w", err)
+			}
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no config file (%s) found in %s or its parent directories", strings.Join(FileNames, ", "), start)
+	}
+	return files, nil
+}
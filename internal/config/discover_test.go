@@ -0,0 +1,67 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindConfigFiles(t *testing.T) {
+	dir := writeConfigs(t, map[string]string{
+		"repo/.git/HEAD":                        "ref: refs/heads/main\n",
+		"repo/tag-rules.yaml":                   "rules: []\n",
+		"repo/aws/.tftaglint.hcl":               "",
+		"repo/aws/account_prod/tag-rules.yaml":  "rules: []\n",
+		"repo/aws/account_prod/.tftaglint.yaml": "rules: []\n",
+		"tag-rules.yaml":                        "rules: []\n",
+	})
+	repo := filepath.Join(dir, "repo")
+
+	tests := []struct {
+		name string
+		dir  string
+		want []string
+	}{
+		{
+			name: "nested directory",
+			dir:  "aws/account_prod/vpc",
+			want: []string{"tag-rules.yaml", "aws/.tftaglint.hcl", "aws/account_prod/tag-rules.yaml"},
+		},
+		{
+			name: "repository root",
+			dir:  ".",
+			want: []string{"tag-rules.yaml"},
+		},
+		{
+			name: "directory without its own config",
+			dir:  "gcp/analytics",
+			want: []string{"tag-rules.yaml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindConfigFiles(filepath.Join(repo, tt.dir))
+			if err != nil {
+				t.Fatalf("FindConfigFiles() error = %v", err)
+			}
+			var want []string
+			for _, file := range tt.want {
+				want = append(want, filepath.Join(repo, file))
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("FindConfigFiles() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFindConfigFiles_NotFound(t *testing.T) {
+	dir := writeConfigs(t, map[string]string{"repo/.git/HEAD": "ref: refs/heads/main\n"})
+
+	_, err := FindConfigFiles(filepath.Join(dir, "repo", "modules"))
+	if err == nil || !strings.Contains(err.Error(), "no config file (tag-rules.yaml, tag-rules.json") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoadConfig_Formats(t *testing.T) {
+	dir := writeConfigs(t, map[string]string{
+		"envs.txt": "development\nproduction\n",
+		"tag-rules.yaml": `value_sets:
+  teams: [platform, data]
+rules:
+  - name: environment
+    description: Environment tag
+    required_tags: [Environment]
+    required_any_of: [[Owner, Team]]
+    condition:
+      any:
+        - tag: Managed
+          exists: true
+        - not:
+            tag: Tier
+            in: [free]
+    tag_constraints:
+      - tag: Environment
+        allowed_values_file: envs.txt
+        case: lowercase
+      - tag: Team
+        allowed_values: [$teams]
+        max_length: 20
+    dependencies:
+      - when_present: PII
+        value: "true"
+        require: [DataOwner]
+    severity: warning
+global:
+  always_required_tags: [Name]
+  provider_limits: [aws]
+`,
+		"tag-rules.json": `{
+	"value_sets": {"teams": ["platform", "data"]},
+	"rules": [
+		{
+			"name": "environment",
+			"description": "Environment tag",
+			"required_tags": ["Environment"],
+			"required_any_of": [["Owner", "Team"]],
+			"condition": {
+				"any": [
+					{"tag": "Managed", "exists": true},
+					{"not": {"tag": "Tier", "in": ["free"]}}
+				]
+			},
+			"tag_constraints": [
+				{"tag": "Environment", "allowed_values_file": "envs.txt", "case": "lowercase"},
+				{"tag": "Team", "allowed_values": ["$teams"], "max_length": 20}
+			],
+			"dependencies": [
+				{"when_present": "PII", "value": "true", "require": ["DataOwner"]}
+			],
+			"severity": "warning"
+		}
+	],
+	"global": {"always_required_tags": ["Name"], "provider_limits": ["aws"]}
+}
+`,
+		"tag-rules.hcl": `value_sets = {
+  teams = ["platform", "data"]
+}
+
+rule "environment" {
+  description     = "Environment tag"
+  required_tags   = ["Environment"]
+  required_any_of = [["Owner", "Team"]]
+
+  condition {
+    any {
+      tag    = "Managed"
+      exists = true
+    }
+    any {
+      not {
+        tag = "Tier"
+        in  = ["free"]
+      }
+    }
+  }
+
+  tag_constraint "Environment" {
+    allowed_values_file = "envs.txt"
+    case                = "lowercase"
+  }
+
+  tag_constraint "Team" {
+    allowed_values = ["$teams"]
+    max_length     = 20
+  }
+
+  dependency {
+    when_present = "PII"
+    value        = "true"
+    require      = ["DataOwner"]
+  }
+
+  severity = "warning"
+}
+
+global {
+  always_required_tags = ["Name"]
+  provider_limits      = ["aws"]
+}
+`,
+	})
+
+	var want string
+	for _, name := range []string{"tag-rules.yaml", "tag-rules.json", "tag-rules.hcl"} {
+		config, err := LoadConfig(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("LoadConfig(%s) error = %v", name, err)
+		}
+		if !config.Rules[0].TagConstraints[0].AllowedValuesFile.Contains("production") {
+			t.Errorf("%s: expected the values file to be loaded", name)
+		}
+
+		out, err := yaml.Marshal(config)
+		if err != nil {
+			t.Fatalf("yaml.Marshal() error = %v", err)
+		}
+		if want == "" {
+			want = string(out)
+		} else if string(out) != want {
+			t.Errorf("%s: expected the same config as YAML.\nGot:\n%s\nWant:\n%s", name, out, want)
+		}
+	}
+}
+
+func TestLoadConfig_FormatErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr []string
+	}{
+		{
+			name: "json validation errors",
+			file: "tag-rules.json",
+			content: `{
+  "rules": [
+    {"name": "owner", "required_tag": ["Owner"]},
+    {"name": "env", "tag_constraints": [{"tag": "Environment", "case": "upper"}]}
+  ],
+  "global": {"severity": "fatal"}
+}`,
+			wantErr: []string{
+				`:3:23: unknown field "required_tag" in rules (did you mean "required_tags"?)`,
+				`:3:5: rule "owner" has no checks`,
+				`:4:72: invalid case "upper" for "Environment" in rule "env"`,
+				`:6:26: invalid severity "fatal" in global`,
+			},
+		},
+		{
+			name:    "json syntax error",
+			file:    "tag-rules.json",
+			content: "{\n  \"rules\": [\n    {\"name\": \"owner\",}\n  ]\n}",
+			wantErr: []string{`:3:21: invalid character ','`},
+		},
+		{
+			name:    "json duplicate key",
+			file:    "tag-rules.json",
+			content: "{\"global\": {}, \"global\": {}}",
+			wantErr: []string{`:1:16: duplicate key "global"`},
+		},
+		{
+			name: "hcl validation errors",
+			file: "tag-rules.hcl",
+			content: `rule "owner" {
+  required_tag = ["Owner"]
+}
+
+rule "env" {
+  tag_constraint "Environment" {
+    case = "upper"
+  }
+}
+
+globals {
+  severity = "fatal"
+}
+`,
+			wantErr: []string{
+				`:1:1: rule "owner" has no checks`,
+				`:2:3: unknown field "required_tag" in rules (did you mean "required_tags"?)`,
+				`:7:12: invalid case "upper" for "Environment" in rule "env"`,
+				`:11:1: unknown field "globals" in config (did you mean "global"?)`,
+			},
+		},
+		{
+			name:    "hcl variables",
+			file:    "tag-rules.hcl",
+			content: "rule \"owner\" {\n  required_tags = [var.owner]\n}\n",
+			wantErr: []string{`:2:20: Variables not allowed`},
+		},
+		{
+			name:    "hcl unexpected label",
+			file:    "tag-rules.hcl",
+			content: "global \"default\" {\n  always_required_tags = [\"Name\"]\n}\n",
+			wantErr: []string{`:1:8: unexpected label on global block`},
+		},
+		{
+			name:    "hcl syntax error",
+			file:    "tag-rules.hcl",
+			content: "rule \"owner\" {\n  required_tags = [\"Owner\"\n}\n",
+			wantErr: []string{`:3:1: Missing item separator`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeConfigs(t, map[string]string{tt.file: tt.content})
+			configPath := filepath.Join(dir, tt.file)
+			_, err := LoadConfig(configPath)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), configPath+want) {
+					t.Errorf("Expected error to contain %q, got:\n%s", configPath+want, err.Error())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"gopkg.in/yaml.v3"
+)
+
+// hclBlockLabels are the fields set by the label of blocks that add to a list,
+// e.g. rule "require-owner" { ... }
+var hclBlockLabels = map[string]string{
+	"rules":           "name",
+	"tag_constraints": "tag",
+}
+
+// parseHCL parses an HCL config into a YAML document node, so that HCL
+// configs are decoded and validated like YAML ones, with the same positions.
+// Attributes map to fields of the same name, and blocks to struct fields of
+// the same name or to list fields they are the singular of: rule blocks add
+// to rules, tag_constraint blocks to tag_constraints and so on.
+func parseHCL(filename string, data []byte) (*yaml.Node, error) {
+	file, diags := hclsyntax.ParseConfig(data, filename, hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse config file: %w", hclErrors(diags))
+	}
+
+	content, diags := hclBody(file.Body.(*hclsyntax.Body), reflect.TypeOf(Config{}), hcl.Pos{Line: 1, Column: 1})
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("failed to parse config file: %w", hclErrors(diags))
+	}
+
+	return &yaml.Node{Kind: yaml.DocumentNode, Line: 1, Column: 1, Content: []*yaml.Node{content}}, nil
+}
+
+// hclEntry is a key and value of the mapping converted from a body
+type hclEntry struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// hclBody converts a body into a mapping node; t is the type it decodes into,
+// or nil if unknown
+func hclBody(body *hclsyntax.Body, t reflect.Type, pos hcl.Pos) (*yaml.Node, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+	var entries []hclEntry
+	lists := make(map[string]*yaml.Node)
+
+	for name, attr := range body.Attributes {
+		value, valueDiags := hclExpr(attr.Expr)
+		diags = append(diags, valueDiags...)
+		if value != nil {
+			entries = append(entries, hclEntry{hclScalar(name, attr.NameRange.Start), value})
+		}
+	}
+
+	var fields map[string]reflect.StructField
+	if t = derefType(t); t != nil && t.Kind() == reflect.Struct {
+		fields = yamlFields(t)
+	}
+
+	for _, block := range body.Blocks {
+		name, fieldType := hclBlockField(block.Type, fields)
+		var elemType reflect.Type
+		isList := fieldType != nil && fieldType.Kind() == reflect.Slice
+		if isList {
+			elemType = fieldType.Elem()
+		} else {
+			elemType = fieldType
+		}
+
+		value, blockDiags := hclBody(block.Body, elemType, block.TypeRange.Start)
+		diags = append(diags, blockDiags...)
+		diags = append(diags, hclLabel(block, value, name)...)
+
+		if !isList {
+			if _, exists := body.Attributes[name]; exists || hasEntry(entries, name) {
+				diags = append(diags, &hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  fmt.Sprintf("duplicate %s block", block.Type),
+					Subject:  block.TypeRange.Ptr(),
+				})
+				continue
+			}
+			entries = append(entries, hclEntry{hclScalar(name, block.TypeRange.Start), value})
+			continue
+		}
+
+		list, ok := lists[name]
+		if !ok {
+			list = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: block.TypeRange.Start.Line, Column: block.TypeRange.Start.Column}
+			lists[name] = list
+			entries = append(entries, hclEntry{hclScalar(name, block.TypeRange.Start), list})
+		}
+		list.Content = append(list.Content, value)
+	}
+
+	// Attributes are unordered; keep the order of the source
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].key, entries[j].key
+		return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+	})
+
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: pos.Line, Column: pos.Column}
+	for _, entry := range entries {
+		node.Content = append(node.Content, entry.key, entry.value)
+	}
+	return node, diags
+}
+
+// hclBlockField returns the yaml name and type of the field a block sets: the
+// field of the same name, or the list of structs the block type is the
+// singular of. Unknown block types keep their name so that they are reported
+// as unknown fields.
+func hclBlockField(blockType string, fields map[string]reflect.StructField) (string, reflect.Type) {
+	if field, ok := fields[blockType]; ok {
+		return blockType, field.Type
+	}
+	for name, field := range fields {
+		if field.Type.Kind() == reflect.Slice && derefType(field.Type.Elem()).Kind() == reflect.Struct && singular(name) == blockType {
+			return name, field.Type
+		}
+	}
+	return blockType, nil
+}
+
+// hclLabel adds the field set by the block's label to its mapping node
+func hclLabel(block *hclsyntax.Block, node *yaml.Node, field string) hcl.Diagnostics {
+	if len(block.Labels) == 0 {
+		return nil
+	}
+	labelField, ok := hclBlockLabels[field]
+	if !ok || len(block.Labels) > 1 {
+		return hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("unexpected label on %s block", block.Type),
+			Subject:  block.LabelRanges[0].Ptr(),
+		}}
+	}
+	if mappingValue(node, labelField) != nil {
+		return hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("%s of %s block is given both as label and as attribute", labelField, block.Type),
+			Subject:  block.LabelRanges[0].Ptr(),
+		}}
+	}
+
+	start := block.LabelRanges[0].Start
+	node.Content = append([]*yaml.Node{hclScalar(labelField, start), hclScalar(block.Labels[0], start)}, node.Content...)
+	return nil
+}
+
+// hclExpr converts an expression into a node. Tuples and objects are
+// converted item by item to keep their positions; other expressions must
+// evaluate to a literal.
+func hclExpr(expr hclsyntax.Expression) (*yaml.Node, hcl.Diagnostics) {
+	start := expr.Range().Start
+
+	switch e := expr.(type) {
+	case *hclsyntax.TupleConsExpr:
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: start.Line, Column: start.Column}
+		var diags hcl.Diagnostics
+		for _, itemExpr := range e.Exprs {
+			item, itemDiags := hclExpr(itemExpr)
+			diags = append(diags, itemDiags...)
+			if item != nil {
+				node.Content = append(node.Content, item)
+			}
+		}
+		return node, diags
+	case *hclsyntax.ObjectConsExpr:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: start.Line, Column: start.Column}
+		var diags hcl.Diagnostics
+		for _, item := range e.Items {
+			key, keyDiags := item.KeyExpr.Value(nil)
+			diags = append(diags, keyDiags...)
+			if keyDiags.HasErrors() || key.IsNull() || key.Type() != cty.String {
+				diags = append(diags, hclUnsupported(item.KeyExpr, "object keys must be strings"))
+				continue
+			}
+			value, valueDiags := hclExpr(item.ValueExpr)
+			diags = append(diags, valueDiags...)
+			if value != nil {
+				node.Content = append(node.Content, hclScalar(key.AsString(), item.KeyExpr.StartRange().Start), value)
+			}
+		}
+		return node, diags
+	}
+
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	node := &yaml.Node{Kind: yaml.ScalarNode, Line: start.Line, Column: start.Column}
+	switch {
+	case value.IsNull():
+		node.Tag, node.Value = "!!null", "null"
+	case value.Type() == cty.String:
+		node.Tag, node.Value = "!!str", value.AsString()
+	case value.Type() == cty.Number:
+		number := value.AsBigFloat()
+		if number.IsInt() {
+			node.Tag, node.Value = "!!int", number.Text('f', -1)
+		} else {
+			node.Tag, node.Value = "!!float", number.Text('g', -1)
+		}
+	case value.Type() == cty.Bool:
+		node.Tag, node.Value = "!!bool", fmt.Sprint(value.True())
+	default:
+		return nil, hcl.Diagnostics{hclUnsupported(expr, "use a string, number, bool, list or object")}
+	}
+	return node, nil
+}
+
+func hclScalar(value string, pos hcl.Pos) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Line: pos.Line, Column: pos.Column}
+}
+
+func hclUnsupported(expr hclsyntax.Expression, detail string) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "unsupported value",
+		Detail:   detail,
+		Subject:  expr.StartRange().Ptr(),
+	}
+}
+
+// hclErrors converts diagnostics into validation errors
+func hclErrors(diags hcl.Diagnostics) ValidationErrors {
+	var errs ValidationErrors
+	for _, diag := range diags.Errs() {
+		d, ok := diag.(*hcl.Diagnostic)
+		if !ok {
+			continue
+		}
+		message := d.Summary
+		if d.Detail != "" {
+			message += ": " + d.Detail
+		}
+		err := ValidationError{Message: message}
+		if d.Subject != nil {
+			err.File, err.Line, err.Column = d.Subject.Filename, d.Subject.Start.Line, d.Subject.Start.Column
+		}
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func hasEntry(entries []hclEntry, key string) bool {
+	for _, entry := range entries {
+		if entry.key.Value == key {
+			return true
+		}
+	}
+	return false
+}
+
+// singular returns the singular of a plural yaml field name
+func singular(name string) string {
+	if strings.HasSuffix(name, "ies") {
+		return strings.TrimSuffix(name, "ies") + "y"
+	}
+	return strings.TrimSuffix(name, "s")
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseJSON parses a JSON config into a YAML document node, so that JSON
+// configs are decoded and validated like YAML ones, with the same positions
+func parseJSON(filename string, data []byte) (*yaml.Node, error) {
+	p := &jsonParser{file: filename, data: data, dec: json.NewDecoder(bytes.NewReader(data))}
+	p.dec.UseNumber()
+
+	tok, offset, err := p.next()
+	if err != nil {
+		return nil, p.syntaxError(err)
+	}
+	content, err := p.value(tok, offset)
+	if err != nil {
+		return nil, err
+	}
+	if _, offset, err := p.next(); err != io.EOF {
+		return nil, p.errorAt(offset, "unexpected data after the top-level value")
+	}
+
+	return &yaml.Node{Kind: yaml.DocumentNode, Line: 1, Column: 1, Content: []*yaml.Node{content}}, nil
+}
+
+type jsonParser struct {
+	file string
+	data []byte
+	dec  *json.Decoder
+}
+
+// next returns the next token and the offset it starts at
+func (p *jsonParser) next() (json.Token, int, error) {
+	offset := int(p.dec.InputOffset())
+	for offset < len(p.data) && strings.IndexByte(" \t\r\n,:", p.data[offset]) >= 0 {
+		offset++
+	}
+	tok, err := p.dec.Token()
+	return tok, offset, err
+}
+
+// value converts the value starting with tok into a node
+func (p *jsonParser) value(tok json.Token, offset int) (*yaml.Node, error) {
+	line, column := p.position(offset)
+	node := &yaml.Node{Line: line, Column: column}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			node.Kind, node.Tag = yaml.MappingNode, "!!map"
+			seen := make(map[string]bool)
+			for p.dec.More() {
+				keyTok, keyOffset, err := p.next()
+				if err != nil {
+					return nil, p.syntaxError(err)
+				}
+				key := keyTok.(string)
+				if seen[key] {
+					return nil, p.errorAt(keyOffset, fmt.Sprintf("duplicate key %q", key))
+				}
+				seen[key] = true
+				keyNode, _ := p.value(keyTok, keyOffset)
+
+				valueTok, valueOffset, err := p.next()
+				if err != nil {
+					return nil, p.syntaxError(err)
+				}
+				valueNode, err := p.value(valueTok, valueOffset)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, keyNode, valueNode)
+			}
+		} else {
+			node.Kind, node.Tag = yaml.SequenceNode, "!!seq"
+			for p.dec.More() {
+				itemTok, itemOffset, err := p.next()
+				if err != nil {
+					return nil, p.syntaxError(err)
+				}
+				item, err := p.value(itemTok, itemOffset)
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, item)
+			}
+		}
+		// Closing delimiter
+		if _, _, err := p.next(); err != nil {
+			return nil, p.syntaxError(err)
+		}
+	case string:
+		node.Kind, node.Tag, node.Value = yaml.ScalarNode, "!!str", t
+	case json.Number:
+		node.Kind, node.Tag, node.Value = yaml.ScalarNode, "!!int", t.String()
+		if strings.ContainsAny(t.String(), ".eE") {
+			node.Tag = "!!float"
+		}
+	case bool:
+		node.Kind, node.Tag, node.Value = yaml.ScalarNode, "!!bool", fmt.Sprint(t)
+	case nil:
+		node.Kind, node.Tag, node.Value = yaml.ScalarNode, "!!null", "null"
+	}
+
+	return node, nil
+}
+
+// position returns the line and column of a byte offset
+func (p *jsonParser) position(offset int) (int, int) {
+	offset = min(offset, len(p.data))
+	before := p.data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	lineStart := bytes.LastIndexByte(before, '\n') + 1
+	return line, utf8.RuneCount(before[lineStart:]) + 1
+}
+
+func (p *jsonParser) errorAt(offset int, message string) error {
+	line, column := p.position(offset)
+	return fmt.Errorf("failed to parse config file: %w", ValidationErrors{{File: p.file, Line: line, Column: column, Message: message}})
+}
+
+func (p *jsonParser) syntaxError(err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return p.errorAt(max(int(syntaxErr.Offset)-1, 0), syntaxErr.Error())
+	}
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return p.errorAt(len(p.data), "unexpected end of JSON input")
+	}
+	return fmt.Errorf("failed to parse config file: %w", err)
+}
@@ -0,0 +1,222 @@
+package config
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Lookup ties the values of several tags together through a table. The key
+// tag selects the rows, and the other columns list the values the
+// corresponding tags may take for that key.
+type Lookup struct {
+	Key     string              `yaml:"key,omitempty"`     // Tag whose value selects the rows
+	Columns []string            `yaml:"columns,omitempty"` // Tags to check; defaults to every column except the key
+	Rows    []map[string]string `yaml:"rows,omitempty"`    // Inline rows, combined with those from file
+	File    *TableFile          `yaml:"file,omitempty"`
+
+	table []map[string]string            // Inline and file rows
+	index map[string][]map[string]string // Rows by key value
+}
+
+// TableFile is a CSV file with a header row or a JSON array of objects. It is
+// written either as a path or as a mapping with the path and format.
+type TableFile struct {
+	Path   string `yaml:"path,omitempty"`   // Relative to the config file
+	Format string `yaml:"format,omitempty"` // csv or json; detected from the file extension if empty
+}
+
+func (f *TableFile) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		f.Path = node.Value
+		return nil
+	}
+	type plain TableFile
+	return node.Decode((*plain)(f))
+}
+
+// Table returns the inline and file rows of the lookup table
+func (l *Lookup) Table() []map[string]string {
+	if l.table == nil {
+		return l.Rows
+	}
+	return l.table
+}
+
+// RowsFor returns the rows whose key column has the given value
+func (l *Lookup) RowsFor(key string) []map[string]string {
+	if l.index != nil {
+		return l.index[key]
+	}
+	var rows []map[string]string
+	for _, row := range l.Table() {
+		if row[l.Key] == key {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// Keys returns the distinct values of the key column
+func (l *Lookup) Keys() []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, row := range l.Table() {
+		if key := row[l.Key]; !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// CheckedColumns returns the columns whose tags are checked against the table
+func (l *Lookup) CheckedColumns() []string {
+	if len(l.Columns) > 0 {
+		return l.Columns
+	}
+	seen := map[string]bool{l.Key: true}
+	var columns []string
+	for _, row := range l.Table() {
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// checkLookup validates a lookup table, loads its file and indexes the rows
+func (cv *configValidator) checkLookup(lookup *Lookup, node *yaml.Node, ruleName string) {
+	if lookup.Key == "" {
+		cv.addError(node, "lookup in rule %q has no key", ruleName)
+		return
+	}
+
+	table := append([]map[string]string(nil), lookup.Rows...)
+	if lookup.File != nil {
+		rows, ok := cv.loadTableFile(lookup.File, nodeOr(mappingValue(node, "file"), node), ruleName)
+		if !ok {
+			return
+		}
+		table = append(table, rows...)
+	}
+	if len(table) == 0 {
+		cv.addError(node, "lookup in rule %q has no rows", ruleName)
+		return
+	}
+
+	rowNodes := sequenceItems(mappingValue(node, "rows"))
+	for i, row := range table {
+		if row[lookup.Key] != "" {
+			continue
+		}
+		if i < len(rowNodes) {
+			cv.addError(rowNodes[i], "lookup row in rule %q has no value for key %q", ruleName, lookup.Key)
+		} else {
+			cv.addError(nodeOr(mappingValue(node, "file"), node), "lookup row %d of %s in rule %q has no value for key %q", i-len(lookup.Rows)+1, lookup.File.Path, ruleName, lookup.Key)
+		}
+		return
+	}
+
+	lookup.table = table
+	lookup.index = make(map[string][]map[string]string)
+	for _, row := range table {
+		lookup.index[row[lookup.Key]] = append(lookup.index[row[lookup.Key]], row)
+	}
+
+	columnNodes := sequenceItems(mappingValue(node, "columns"))
+	for i, column := range lookup.Columns {
+		if !tableHasColumn(table, column) {
+			columnNode := nodeOr(mappingValue(node, "columns"), node)
+			if i < len(columnNodes) {
+				columnNode = columnNodes[i]
+			}
+			cv.addError(columnNode, "lookup column %q in rule %q is not in the table", column, ruleName)
+		}
+	}
+}
+
+func tableHasColumn(table []map[string]string, column string) bool {
+	for _, row := range table {
+		if _, ok := row[column]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// loadTableFile reads a lookup table file relative to the config file, reusing
+// tables that were already loaded
+func (cv *configValidator) loadTableFile(file *TableFile, node *yaml.Node, ruleName string) ([]map[string]string, bool) {
+	if file.Path == "" {
+		cv.addError(node, "lookup file in rule %q has no path", ruleName)
+		return nil, false
+	}
+	format := fileFormat(file.Path, file.Format)
+	if format != FormatCSV && format != FormatJSON {
+		cv.addError(nodeOr(mappingValue(node, "format"), node), "invalid format %q for lookup file in rule %q (use csv or json)", format, ruleName)
+		return nil, false
+	}
+
+	path := cv.resolvePath(file.Path)
+	key := path + "\x00" + format
+	if rows, ok := cv.tableFiles[key]; ok {
+		return rows, true
+	}
+
+	rows, err := readTableFile(path, format)
+	if err != nil {
+		cv.addError(nodeOr(mappingValue(node, "path"), node), "failed to load lookup file %s in rule %q: %v", file.Path, ruleName, err)
+		return nil, false
+	}
+
+	if cv.tableFiles == nil {
+		cv.tableFiles = make(map[string][]map[string]string)
+	}
+	cv.tableFiles[key] = rows
+	return rows, true
+}
+
+// readTableFile reads the rows of a CSV or JSON table
+func readTableFile(path, format string) ([]map[string]string, error) {
+	content, err := readDataFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == FormatJSON {
+		var rows []map[string]string
+		if err := json.Unmarshal([]byte(content), &rows); err != nil {
+			return nil, fmt.Errorf("expected a JSON array of objects with string values: %w", err)
+		}
+		return rows, nil
+	}
+
+	reader := csv.NewReader(strings.NewReader(content))
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			row[strings.TrimSpace(column)] = strings.TrimSpace(record[i])
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig_Lookup(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"teams.csv":  "Team,CostCenter,Owner\nplatform,CC-10001,alice@example.com\nplatform,CC-10001,bob@example.com\n",
+		"teams.json": `[{"Team": "data", "CostCenter": "CC-20001", "Owner": "carol@example.com"}]`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write table file: %v", err)
+		}
+	}
+
+	configPath := filepath.Join(dir, "tag-rules.yaml")
+	content := `rules:
+  - name: csv
+    lookup:
+      key: Team
+      file: teams.csv
+  - name: json
+    lookup:
+      key: Team
+      columns: [CostCenter]
+      file:
+        path: teams.json
+      rows:
+        - {Team: security, CostCenter: CC-30001}
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	csvLookup := config.Rules[0].Lookup
+	if got := len(csvLookup.RowsFor("platform")); got != 2 {
+		t.Errorf("Expected 2 platform rows, got %d", got)
+	}
+	if got := csvLookup.CheckedColumns(); !reflect.DeepEqual(got, []string{"CostCenter", "Owner"}) {
+		t.Errorf("Expected checked columns [CostCenter Owner], got %v", got)
+	}
+
+	jsonLookup := config.Rules[1].Lookup
+	if got := jsonLookup.Keys(); !reflect.DeepEqual(got, []string{"security", "data"}) {
+		t.Errorf("Expected inline rows before file rows, got keys %v", got)
+	}
+	if got := jsonLookup.CheckedColumns(); !reflect.DeepEqual(got, []string{"CostCenter"}) {
+		t.Errorf("Expected checked columns [CostCenter], got %v", got)
+	}
+}
+
+func TestLoadConfig_LookupErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		lookup    string
+		wantError string
+	}{
+		{
+			name: "no key",
+			lookup: `lookup:
+      rows:
+        - {Team: platform}`,
+			wantError: `:4:7: lookup in rule "teams" has no key`,
+		},
+		{
+			name: "no rows",
+			lookup: `lookup:
+      key: Team`,
+			wantError: `:4:7: lookup in rule "teams" has no rows`,
+		},
+		{
+			name: "row without key",
+			lookup: `lookup:
+      key: Team
+      rows:
+        - {Team: platform, CostCenter: CC-1}
+        - {CostCenter: CC-2}`,
+			wantError: `:7:11: lookup row in rule "teams" has no value for key "Team"`,
+		},
+		{
+			name: "unknown column",
+			lookup: `lookup:
+      key: Team
+      columns: [CostCentre]
+      rows:
+        - {Team: platform, CostCenter: CC-1}`,
+			wantError: `:5:17: lookup column "CostCentre" in rule "teams" is not in the table`,
+		},
+		{
+			name: "missing file",
+			lookup: `lookup:
+      key: Team
+      file: teams.csv`,
+			wantError: `:5:13: failed to load lookup file teams.csv in rule "teams"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "tag-rules.yaml")
+			content := "rules:\n  - name: teams\n    " + tt.lookup + "\n"
+			if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			_, err := LoadConfig(configPath)
+			var validationErrors ValidationErrors
+			if !errors.As(err, &validationErrors) {
+				t.Fatalf("Expected ValidationErrors, got %v", err)
+			}
+			if !strings.Contains(err.Error(), configPath+tt.wantError) {
+				t.Errorf("Expected error containing %q, got:\n%v", tt.wantError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Global lists that can be replaced instead of appended to when merging
+const (
+	listAlwaysRequiredTags  = "always_required_tags"
+	listIgnoreResourceTypes = "ignore_resource_types"
+	listProviderLimits      = "provider_limits"
+)
+
+var mergeableLists = []string{listAlwaysRequiredTags, listIgnoreResourceTypes, listProviderLimits}
+
+// layer is a config file as loaded, before references are expanded and it is
+// merged with the configs before it
+type layer struct {
+	config *Config
+	file   string
+	root   *yaml.Node // For the positions of references
+}
+
+// loadLayers loads a config file and the configs it extends, in the order
+// they are merged: extended configs first, depth first, then the file itself.
+// visiting holds the files being loaded, to detect cycles. loaded holds the
+// files already loaded: a file reached through several extends paths is only
+// loaded at its first position, so a later copy cannot bring back rules that
+// the layers after it disabled.
+func loadLayers(filename string, visiting, loaded map[string]bool) ([]*layer, error) {
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve config file path: %w", err)
+	}
+	if visiting[path] {
+		return nil, fmt.Errorf("%s: extends cycle", filename)
+	}
+	if loaded[path] {
+		return nil, nil
+	}
+	visiting[path] = true
+	defer delete(visiting, path)
+
+	fileLayer, err := loadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var layers []*layer
+	for _, parent := range fileLayer.config.Extends {
+		if !filepath.IsAbs(parent) {
+			parent = filepath.Join(filepath.Dir(filename), parent)
+		}
+		parentLayers, err := loadLayers(parent, visiting, loaded)
+		if err != nil {
+			return nil, fmt.Errorf("%s: extends %s: %w", filename, parent, err)
+		}
+		layers = append(layers, parentLayers...)
+	}
+
+	loaded[path] = true
+	return append(layers, fileLayer), nil
+}
+
+// merge layers overlay over base. Value sets and tag groups in overlay
+// replace those of the same name. Rules in overlay replace base rules of the
+// same name, and disabled rules remove them; other rules are appended. Global
+// lists are appended to unless overlay lists them in global.replace, and a
+// global severity in overlay takes precedence.
+func merge(base, overlay *Config) (*Config, error) {
+	merged := &Config{
+		ValueSets: mergeSets(base.ValueSets, overlay.ValueSets),
+		TagGroups: mergeSets(base.TagGroups, overlay.TagGroups),
+		Rules:     slices.Clone(base.Rules),
+		Global:    base.Global,
+	}
+
+	var errs ValidationErrors
+	for _, rule := range overlay.Rules {
+		i := slices.IndexFunc(merged.Rules, func(r Rule) bool { return r.Name == rule.Name })
+		switch {
+		case rule.Disabled && i < 0:
+			err := rule.origin
+			err.Message = fmt.Sprintf("rule %q is disabled but not defined in an earlier config", rule.Name)
+			errs = append(errs, err)
+		case rule.Disabled:
+			merged.Rules = slices.Delete(merged.Rules, i, i+1)
+		case i >= 0:
+			merged.Rules[i] = rule
+		default:
+			merged.Rules = append(merged.Rules, rule)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	merged.Global.AlwaysRequiredTags = mergeList(base.Global.AlwaysRequiredTags, overlay.Global.AlwaysRequiredTags,
+		slices.Contains(overlay.Global.Replace, listAlwaysRequiredTags))
+	merged.Global.IgnoreResourceTypes = mergeList(base.Global.IgnoreResourceTypes, overlay.Global.IgnoreResourceTypes,
+		slices.Contains(overlay.Global.Replace, listIgnoreResourceTypes))
+	merged.Global.ProviderLimits = mergeList(base.Global.ProviderLimits, overlay.Global.ProviderLimits,
+		slices.Contains(overlay.Global.Replace, listProviderLimits))
+	if overlay.Global.Severity != "" {
+		merged.Global.Severity = overlay.Global.Severity
+	}
+	merged.Global.Replace = nil
+
+	return merged, nil
+}
+
+// mergeSets returns the sets of base and overlay, with those of overlay
+// replacing sets of the same name
+func mergeSets(base, overlay map[string][]string) map[string][]string {
+	if len(base) == 0 && len(overlay) == 0 {
+		return nil
+	}
+	merged := maps.Clone(base)
+	if merged == nil {
+		merged = make(map[string][]string)
+	}
+	maps.Copy(merged, overlay)
+	return merged
+}
+
+// mergeList appends the overlay items missing from base, or returns overlay
+// if it replaces base
+func mergeList(base, overlay []string, replace bool) []string {
+	if replace {
+		return slices.Clone(overlay)
+	}
+	merged := slices.Clone(base)
+	for _, item := range overlay {
+		if !slices.Contains(merged, item) {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,194 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeConfigs(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+	}
+	return dir
+}
+
+func ruleNames(config *Config) []string {
+	var names []string
+	for _, rule := range config.Rules {
+		names = append(names, rule.Name)
+	}
+	return names
+}
+
+func TestLoadConfig_Extends(t *testing.T) {
+	dir := writeConfigs(t, map[string]string{
+		"shared/base.yaml": `rules:
+  - name: owner
+    required_tags: [Owner]
+  - name: cost-center
+    required_tags: [CostCenter]
+  - name: environment
+    required_tags: [Environment]
+global:
+  always_required_tags: [Name]
+  ignore_resource_types: [aws_iam_role]
+  provider_limits: [aws]
+  severity: warning
+`,
+		"shared/security.yaml": `extends: [base.yaml]
+rules:
+  - name: no-public
+    forbidden_tags: [Public]
+`,
+		"team/tag-rules.yaml": `extends:
+  - ../shared/security.yaml
+rules:
+  - name: owner
+    required_tags: [Owner, Team]
+  - name: environment
+    disabled: true
+  - name: team
+    required_tags: [Team]
+global:
+  always_required_tags: [Name, Project]
+  ignore_resource_types: [aws_kms_key]
+  provider_limits: [gcp]
+  replace: [ignore_resource_types]
+`,
+	})
+
+	config, err := LoadConfig(filepath.Join(dir, "team/tag-rules.yaml"))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	if got, want := ruleNames(config), []string{"owner", "cost-center", "no-public", "team"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected rules %v, got %v", want, got)
+	}
+	if got := config.Rules[0].RequiredTags; !reflect.DeepEqual(got, []string{"Owner", "Team"}) {
+		t.Errorf("Expected overridden rule to require [Owner Team], got %v", got)
+	}
+	if got := config.Global.AlwaysRequiredTags; !reflect.DeepEqual(got, []string{"Name", "Project"}) {
+		t.Errorf("Expected appended always_required_tags [Name Project], got %v", got)
+	}
+	if got := config.Global.IgnoreResourceTypes; !reflect.DeepEqual(got, []string{"aws_kms_key"}) {
+		t.Errorf("Expected replaced ignore_resource_types [aws_kms_key], got %v", got)
+	}
+	if got := config.Global.ProviderLimits; !reflect.DeepEqual(got, []string{"aws", "gcp"}) {
+		t.Errorf("Expected appended provider_limits [aws gcp], got %v", got)
+	}
+	if config.Global.Severity != SeverityWarning {
+		t.Errorf("Expected inherited global severity warning, got %q", config.Global.Severity)
+	}
+	if config.Extends != nil || config.Global.Replace != nil {
+		t.Errorf("Expected extends and replace to be resolved, got %v and %v", config.Extends, config.Global.Replace)
+	}
+}
+
+func TestLoadConfig_ExtendsShared(t *testing.T) {
+	dir := writeConfigs(t, map[string]string{
+		"base.yaml": "rules:\n  - name: x\n    required_tags: [Owner]\n",
+		"b.yaml":    "extends: [base.yaml]\nrules:\n  - name: x\n    disabled: true\n",
+		"c.yaml":    "extends: [base.yaml]\nrules:\n  - name: team\n    required_tags: [Team]\n",
+		"a.yaml":    "extends: [b.yaml, c.yaml]\n",
+	})
+
+	config, err := LoadConfig(filepath.Join(dir, "a.yaml"))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := ruleNames(config); !reflect.DeepEqual(got, []string{"team"}) {
+		t.Errorf("Expected base.yaml to be loaded once and rule x to stay disabled, got rules %v", got)
+	}
+}
+
+func TestLoadConfig_ExtendsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr []string
+	}{
+		{
+			name: "disabled rule not defined",
+			files: map[string]string{
+				"base.yaml":      "rules:\n  - name: owner\n    required_tags: [Owner]\n",
+				"tag-rules.yaml": "extends: [base.yaml]\nrules:\n  - name: ownr\n    disabled: true\n",
+			},
+			wantErr: []string{`tag-rules.yaml:3:5: rule "ownr" is disabled but not defined in an earlier config`},
+		},
+		{
+			name: "cycle",
+			files: map[string]string{
+				"a.yaml":         "extends: [tag-rules.yaml]\n",
+				"tag-rules.yaml": "extends: [a.yaml]\n",
+			},
+			wantErr: []string{"extends cycle"},
+		},
+		{
+			name: "missing parent",
+			files: map[string]string{
+				"tag-rules.yaml": "extends: [missing.yaml]\n",
+			},
+			wantErr: []string{"extends", "missing.yaml", "failed to read config file"},
+		},
+		{
+			name: "invalid parent",
+			files: map[string]string{
+				"base.yaml":      "rules:\n  - name: owner\n",
+				"tag-rules.yaml": "extends: [base.yaml]\n",
+			},
+			wantErr: []string{`base.yaml:2:5: rule "owner" has no checks`},
+		},
+		{
+			name: "unknown replace list",
+			files: map[string]string{
+				"tag-rules.yaml": "global:\n  replace: [always_required]\n",
+			},
+			wantErr: []string{`tag-rules.yaml:2:13: unknown list "always_required" in global replace`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeConfigs(t, tt.files)
+			_, err := LoadConfig(filepath.Join(dir, "tag-rules.yaml"))
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected error to contain %q, got %q", want, err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestLoadConfigs(t *testing.T) {
+	dir := writeConfigs(t, map[string]string{
+		"base.yaml":  "rules:\n  - name: owner\n    required_tags: [Owner]\nglobal:\n  always_required_tags: [Name]\n",
+		"local.yaml": "rules:\n  - name: owner\n    disabled: true\n  - name: team\n    required_tags: [Team]\nglobal:\n  always_required_tags: [Name, Team]\n",
+	})
+
+	config, err := LoadConfigs([]string{filepath.Join(dir, "base.yaml"), filepath.Join(dir, "local.yaml")})
+	if err != nil {
+		t.Fatalf("LoadConfigs() error = %v", err)
+	}
+	if got := ruleNames(config); !reflect.DeepEqual(got, []string{"team"}) {
+		t.Errorf("Expected rules [team], got %v", got)
+	}
+	if got := config.Global.AlwaysRequiredTags; !reflect.DeepEqual(got, []string{"Name", "Team"}) {
+		t.Errorf("Expected always_required_tags without duplicates, got %v", got)
+	}
+}
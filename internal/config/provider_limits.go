@@ -0,0 +1,86 @@
+package config
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ProviderLimits are the tag limits a cloud provider enforces. They apply to
+// managed resources whose type starts with ResourcePrefix; zero values mean
+// no limit.
+type ProviderLimits struct {
+	Name           string // Name used in global.provider_limits
+	Title          string // Provider name for messages
+	ResourcePrefix string
+
+	MaxTags        int
+	MaxKeyLength   int // In characters
+	MaxValueLength int // In characters
+
+	ReservedPrefixes  []string // Key prefixes reserved by the provider, matched case-insensitively
+	ForbiddenKeyChars string
+
+	KeyPattern   *regexp.Regexp // Keys must match, if set
+	KeyCharset   string         // Describes KeyPattern for messages
+	ValuePattern *regexp.Regexp // Values must match, if set
+	ValueCharset string         // Describes ValuePattern for messages
+}
+
+// Provider limit profiles
+const (
+	ProviderAWS   = "aws"
+	ProviderAzure = "azure"
+	ProviderGCP   = "gcp"
+)
+
+var providerLimits = map[string]*ProviderLimits{
+	ProviderAWS: {
+		Name:             ProviderAWS,
+		Title:            "AWS",
+		ResourcePrefix:   "aws_",
+		MaxTags:          50,
+		MaxKeyLength:     128,
+		MaxValueLength:   256,
+		ReservedPrefixes: []string{"aws:"},
+	},
+	ProviderAzure: {
+		Name:              ProviderAzure,
+		Title:             "Azure",
+		ResourcePrefix:    "azurerm_",
+		MaxTags:           50,
+		MaxKeyLength:      512,
+		MaxValueLength:    256,
+		ForbiddenKeyChars: `<>%&\?/`,
+	},
+	ProviderGCP: {
+		Name:           ProviderGCP,
+		Title:          "GCP",
+		ResourcePrefix: "google_",
+		MaxTags:        64,
+		MaxKeyLength:   63,
+		MaxValueLength: 63,
+		KeyPattern:     regexp.MustCompile(`^\p{Ll}[\p{Ll}\p{Lo}\p{N}_-]*$`),
+		KeyCharset:     "lowercase letters, digits, '_' and '-', starting with a letter",
+		ValuePattern:   regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}_-]*$`),
+		ValueCharset:   "lowercase letters, digits, '_' and '-'",
+	},
+}
+
+// providerNames lists the supported profiles for error messages
+var providerNames = []string{ProviderAWS, ProviderAzure, ProviderGCP}
+
+// LookupProviderLimits returns the limit profile with the given name
+func LookupProviderLimits(name string) (*ProviderLimits, bool) {
+	limits, ok := providerLimits[name]
+	return limits, ok
+}
+
+// ReservedPrefix returns the reserved prefix key starts with, if any
+func (p *ProviderLimits) ReservedPrefix(key string) (string, bool) {
+	for _, prefix := range p.ReservedPrefixes {
+		if strings.HasPrefix(strings.ToLower(key), prefix) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
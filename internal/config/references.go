@@ -0,0 +1,159 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReferencePrefix marks a list item that names a value set or tag group. A
+// literal value starting with the prefix is written with it doubled.
+const ReferencePrefix = "$"
+
+// checkSets reports empty value sets and tag groups, and references between sets
+func (cv *configValidator) checkSets(config *Config, root *yaml.Node) {
+	for _, section := range []struct {
+		key  string
+		kind string
+		sets map[string][]string
+	}{
+		{"value_sets", "value set", config.ValueSets},
+		{"tag_groups", "tag group", config.TagGroups},
+	} {
+		sectionNode := mappingValue(documentContent(root), section.key)
+		for name, items := range section.sets {
+			node := nodeOr(mappingKey(sectionNode, name), &yaml.Node{})
+			if len(items) == 0 {
+				cv.addError(node, "%s %q is empty", section.kind, name)
+			}
+			for i, item := range items {
+				if isReference(item) {
+					cv.addError(listItem(mappingValue(sectionNode, name), i, node), "%s %q references %q; sets cannot reference other sets", section.kind, name, item)
+				}
+			}
+		}
+	}
+}
+
+// expandReferences replaces references in the config's allowed_values with
+// the named value sets, and those in its tag lists with the named tag groups
+func (cv *configValidator) expandReferences(config *Config, root *yaml.Node, valueSets, tagGroups map[string][]string) {
+	ruleNodes := sequenceItems(mappingValue(documentContent(root), "rules"))
+	for i := range config.Rules {
+		rule := &config.Rules[i]
+		node := &yaml.Node{}
+		if i < len(ruleNodes) {
+			node = ruleNodes[i]
+		}
+
+		for _, list := range []struct {
+			key   string
+			items *[]string
+		}{
+			{"required_tags", &rule.RequiredTags},
+			{"forbidden_tags", &rule.ForbiddenTags},
+			{"protected_tags", &rule.ProtectedTags},
+		} {
+			*list.items = cv.expandList(*list.items, mappingValue(node, list.key), node, tagGroups, "tag group", fmt.Sprintf("%s of rule %q", list.key, rule.Name))
+		}
+
+		for _, list := range []struct {
+			key    string
+			groups [][]string
+		}{
+			{"required_any_of", rule.RequiredAnyOf},
+			{"required_one_of", rule.RequiredOneOf},
+			{"mutually_exclusive", rule.MutuallyExclusive},
+		} {
+			for j := range list.groups {
+				groupNode := listItem(mappingValue(node, list.key), j, node)
+				errors := len(cv.errors)
+				list.groups[j] = cv.expandList(list.groups[j], groupNode, groupNode, tagGroups, "tag group", fmt.Sprintf("%s of rule %q", list.key, rule.Name))
+				// Groups with references are only checked once expanded; see checkTagGroups
+				if len(cv.errors) == errors && len(list.groups[j]) < 2 {
+					cv.addError(groupNode, "%s group in rule %q needs at least two tags", list.key, rule.Name)
+				}
+			}
+		}
+
+		for j := range rule.Dependencies {
+			dependency := &rule.Dependencies[j]
+			dependencyNode := listItem(mappingValue(node, "dependencies"), j, node)
+			dependency.Require = cv.expandList(dependency.Require, mappingValue(dependencyNode, "require"), dependencyNode, tagGroups, "tag group", fmt.Sprintf("require of dependency on %q in rule %q", dependency.Trigger(), rule.Name))
+		}
+
+		for j := range rule.TagConstraints {
+			constraint := &rule.TagConstraints[j]
+			constraintNode := listItem(mappingValue(node, "tag_constraints"), j, node)
+			constraint.AllowedValues = cv.expandList(constraint.AllowedValues, mappingValue(constraintNode, "allowed_values"), constraintNode, valueSets, "value set", fmt.Sprintf("allowed_values for %q in rule %q", constraint.Tag, rule.Name))
+		}
+	}
+
+	globalNode := mappingValue(documentContent(root), "global")
+	config.Global.AlwaysRequiredTags = cv.expandList(config.Global.AlwaysRequiredTags, mappingValue(globalNode, "always_required_tags"), nodeOr(globalNode, &yaml.Node{}), tagGroups, "tag group", "always_required_tags")
+}
+
+// isReference reports whether a list item names a value set or tag group
+func isReference(item string) bool {
+	return strings.HasPrefix(item, ReferencePrefix) && !strings.HasPrefix(item, ReferencePrefix+ReferencePrefix)
+}
+
+// expandList replaces references in items with the sets they name, reporting
+// undefined ones at their position in listNode
+func (cv *configValidator) expandList(items []string, listNode, fallback *yaml.Node, sets map[string][]string, kind, context string) []string {
+	if len(items) == 0 {
+		return items
+	}
+
+	expanded := make([]string, 0, len(items))
+	for i, item := range items {
+		switch {
+		case strings.HasPrefix(item, ReferencePrefix+ReferencePrefix):
+			expanded = append(expanded, item[len(ReferencePrefix):])
+		case strings.HasPrefix(item, ReferencePrefix):
+			name := item[len(ReferencePrefix):]
+			values, ok := sets[name]
+			if !ok {
+				cv.addError(listItem(listNode, i, fallback), "undefined %s %q in %s%s", kind, name, context, suggestion(name, setNames(sets)))
+				continue
+			}
+			expanded = append(expanded, values...)
+		default:
+			expanded = append(expanded, item)
+		}
+	}
+	return expanded
+}
+
+func setNames(sets map[string][]string) []string {
+	names := make([]string, 0, len(sets))
+	for name := range sets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// mappingKey returns the key node for key in a mapping node, or nil
+func mappingKey(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+// listItem returns the i-th item of a sequence node, or fallback
+func listItem(node *yaml.Node, i int, fallback *yaml.Node) *yaml.Node {
+	items := sequenceItems(node)
+	if i < len(items) {
+		return items[i]
+	}
+	return fallback
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig_References(t *testing.T) {
+	dir := writeConfigs(t, map[string]string{
+		"base.yaml": `value_sets:
+  environments: [development, staging, production]
+tag_groups:
+  finance: [CostCenter, BudgetCode]
+`,
+		"tag-rules.yaml": `extends: [base.yaml]
+tag_groups:
+  ownership: [Owner, Team]
+rules:
+  - name: finance
+    required_tags: [$finance, $ownership, Name]
+    required_one_of: [[$ownership]]
+    forbidden_tags: [$$Temp]
+    tag_constraints:
+      - tag: Environment
+        allowed_values: [$environments, sandbox]
+global:
+  always_required_tags: [$ownership]
+`,
+	})
+
+	config, err := LoadConfig(filepath.Join(dir, "tag-rules.yaml"))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	rule := config.Rules[0]
+	if want := []string{"CostCenter", "BudgetCode", "Owner", "Team", "Name"}; !reflect.DeepEqual(rule.RequiredTags, want) {
+		t.Errorf("Expected required_tags %v, got %v", want, rule.RequiredTags)
+	}
+	if want := [][]string{{"Owner", "Team"}}; !reflect.DeepEqual(rule.RequiredOneOf, want) {
+		t.Errorf("Expected required_one_of %v, got %v", want, rule.RequiredOneOf)
+	}
+	if want := []string{"$Temp"}; !reflect.DeepEqual(rule.ForbiddenTags, want) {
+		t.Errorf("Expected escaped forbidden_tags %v, got %v", want, rule.ForbiddenTags)
+	}
+	if want := []string{"development", "staging", "production", "sandbox"}; !reflect.DeepEqual(rule.TagConstraints[0].AllowedValues, want) {
+		t.Errorf("Expected allowed_values %v, got %v", want, rule.TagConstraints[0].AllowedValues)
+	}
+	if want := []string{"Owner", "Team"}; !reflect.DeepEqual(config.Global.AlwaysRequiredTags, want) {
+		t.Errorf("Expected always_required_tags %v, got %v", want, config.Global.AlwaysRequiredTags)
+	}
+	if _, ok := config.ValueSets["environments"]; !ok {
+		t.Errorf("Expected merged value sets to include environments, got %v", config.ValueSets)
+	}
+}
+
+func TestLoadConfig_ReferenceErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr []string
+	}{
+		{
+			name: "undefined references",
+			content: `value_sets:
+  environments: [development, production]
+tag_groups:
+  finance: [CostCenter]
+rules:
+  - name: r
+    required_tags: [$finanse]
+    tag_constraints:
+      - tag: Environment
+        allowed_values: [$environment]
+      - tag: Team
+        allowed_values: [$finance]
+`,
+			wantErr: []string{
+				`:7:21: undefined tag group "finanse" in required_tags of rule "r" (did you mean "finance"?)`,
+				`:10:26: undefined value set "environment" in allowed_values for "Environment" in rule "r" (did you mean "environments"?)`,
+				`:12:26: undefined value set "finance" in allowed_values for "Team" in rule "r"`,
+			},
+		},
+		{
+			name: "tag group with one tag",
+			content: `tag_groups:
+  owner: [Owner]
+rules:
+  - name: r
+    required_any_of: [[$owner]]
+`,
+			wantErr: []string{`:5:23: required_any_of group in rule "r" needs at least two tags`},
+		},
+		{
+			name: "empty and nested sets",
+			content: `value_sets:
+  empty: []
+  nested: [$empty, other]
+rules:
+  - name: r
+    required_tags: [Owner]
+`,
+			wantErr: []string{
+				`:2:3: value set "empty" is empty`,
+				`:3:12: value set "nested" references "$empty"; sets cannot reference other sets`,
+			},
+		},
+		{
+			name: "not defined by the extended config",
+			content: `extends: [base.yaml]
+rules:
+  - name: r
+    required_tags: [$later]
+`,
+			wantErr: []string{`:4:21: undefined tag group "later" in required_tags of rule "r"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeConfigs(t, map[string]string{
+				"tag-rules.yaml": tt.content,
+				"base.yaml":      "rules: []\n",
+			})
+			configPath := filepath.Join(dir, "tag-rules.yaml")
+			_, err := LoadConfig(configPath)
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), configPath+want) {
+					t.Errorf("Expected error to contain %q, got %q", configPath+want, err.Error())
+				}
+			}
+		})
+	}
+}
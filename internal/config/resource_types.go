@@ -0,0 +1,68 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// dataPrefix marks resource type patterns that match data sources
+const dataPrefix = "data."
+
+// regexCache holds the compiled /regex/ resource type patterns
+var regexCache sync.Map
+
+// MatchResourceType reports whether a resource type pattern matches a resource.
+// Patterns are exact names, globs such as aws_s3_*, or regular expressions
+// between slashes such as /^aws_(s3|sqs)_/. Patterns starting with data. match
+// data sources; all other patterns match managed resources.
+func MatchResourceType(pattern string, dataSource bool, resourceType string) bool {
+	pattern, isData := strings.CutPrefix(pattern, dataPrefix)
+	if isData != dataSource {
+		return false
+	}
+
+	if expr, ok := regexPattern(pattern); ok {
+		regex, err := compileCached(expr)
+		return err == nil && regex.MatchString(resourceType)
+	}
+
+	matched, err := path.Match(pattern, resourceType)
+	return err == nil && matched
+}
+
+// validateResourceType reports why a resource type pattern is invalid
+func validateResourceType(pattern string) error {
+	pattern = strings.TrimPrefix(pattern, dataPrefix)
+	if pattern == "" {
+		return fmt.Errorf("empty pattern")
+	}
+	if expr, ok := regexPattern(pattern); ok {
+		_, err := compileCached(expr)
+		return err
+	}
+	_, err := path.Match(pattern, "")
+	return err
+}
+
+// regexPattern returns the expression of a /regex/ pattern
+func regexPattern(pattern string) (string, bool) {
+	if len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		return pattern[1 : len(pattern)-1], true
+	}
+	return "", false
+}
+
+func compileCached(expr string) (*regexp.Regexp, error) {
+	if regex, ok := regexCache.Load(expr); ok {
+		return regex.(*regexp.Regexp), nil
+	}
+	regex, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	regexCache.Store(expr, regex)
+	return regex, nil
+}
@@ -0,0 +1,34 @@
+package config
+
+import "testing"
+
+func TestMatchResourceType(t *testing.T) {
+	tests := []struct {
+		pattern      string
+		dataSource   bool
+		resourceType string
+		want         bool
+	}{
+		{"aws_instance", false, "aws_instance", true},
+		{"aws_instance", false, "aws_instance_profile", false},
+		{"aws_s3_*", false, "aws_s3_bucket_policy", true},
+		{"aws_s3_*", false, "aws_sqs_queue", false},
+		{"aws_?pc", false, "aws_vpc", true},
+		{"/^aws_(s3|sqs)_/", false, "aws_sqs_queue", true},
+		{"/^aws_(s3|sqs)_/", false, "aws_sns_topic", false},
+		{"/iam/", false, "aws_iam_role", true},
+		{"aws_instance", true, "aws_instance", false},
+		{"data.aws_ami", true, "aws_ami", true},
+		{"data.aws_ami", false, "aws_ami", false},
+		{"data.*", true, "google_compute_image", true},
+		{"*", false, "azurerm_resource_group", true},
+		{"*", true, "azurerm_resource_group", false},
+		{"aws_[s3", false, "aws_s3", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchResourceType(tt.pattern, tt.dataSource, tt.resourceType); got != tt.want {
+			t.Errorf("MatchResourceType(%q, %v, %q) = %v, want %v", tt.pattern, tt.dataSource, tt.resourceType, got, tt.want)
+		}
+	}
+}
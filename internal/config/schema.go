@@ -0,0 +1,224 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaURI is the JSON Schema dialect of the generated schema
+const SchemaURI = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema is a JSON Schema document or subschema
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"` // false or a schema
+	Required             []string               `json:"required,omitempty"`
+	AllOf                []*JSONSchema          `json:"allOf,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	Definitions          map[string]*JSONSchema `json:"definitions,omitempty"`
+}
+
+// fieldDescriptions describes every config field, by Go type and yaml name.
+// TestSchema_Descriptions fails if a field is missing.
+var fieldDescriptions = map[string]string{
+	"Config.extends":    "Config files this one is layered over, in order, relative to this file.",
+	"Config.value_sets": "Named lists of values that allowed_values can reference as $name.",
+	"Config.tag_groups": "Named lists of tag keys that required_tags, forbidden_tags, protected_tags, the groups of required_any_of, required_one_of and mutually_exclusive, dependency require lists and always_required_tags can reference as $name.",
+	"Config.rules":      "Rules checked against every resource they apply to.",
+	"Config.global":     "Settings that apply to all resources.",
+
+	"Rule.name":                   "Unique name of the rule, shown in violations. A rule with the name of a rule from an earlier config replaces it.",
+	"Rule.description":            "Description shown with violations of the rule.",
+	"Rule.required_tags":          "Tags that must be present.",
+	"Rule.forbidden_tags":         "Tags that must be absent.",
+	"Rule.required_any_of":        "Groups of tags of which at least one must be present.",
+	"Rule.required_one_of":        "Groups of tags of which exactly one must be present.",
+	"Rule.mutually_exclusive":     "Groups of tags of which at most one may be present.",
+	"Rule.dependencies":           "Tags required depending on the presence or absence of another tag.",
+	"Rule.condition":              "Only apply the rule to resources whose tags match the condition.",
+	"Rule.resource_types":         "Only apply the rule to these resource types. Entries are exact types, globs such as aws_* or /regex/; prefix with data. for data sources.",
+	"Rule.exclude_resource_types": "Do not apply the rule to these resource types, with the same patterns as resource_types.",
+	"Rule.tag_constraints":        "Restrictions on the values of tags; tags that are absent are not checked.",
+	"Rule.tag_patterns":           "Regular expressions every tag key must match.",
+	"Rule.protected_tags":         "Tags that plans may not remove or change (checked with --tag-changes).",
+	"Rule.lookup":                 "Table of allowed combinations of tag values, selected by a key tag.",
+	"Rule.severity":               "Severity of the rule's violations; defaults to error.",
+	"Rule.disabled":               "Remove the rule of the same name defined by an earlier config.",
+
+	"Dependency.when_present": "Require the tags when this tag is present.",
+	"Dependency.when_absent":  "Require the tags when this tag is absent.",
+	"Dependency.value":        "Only require the tags when the when_present tag has this value.",
+	"Dependency.require":      "Tags required when the dependency applies.",
+
+	"Condition.all":        "True if all of the conditions are true.",
+	"Condition.any":        "True if any of the conditions is true.",
+	"Condition.not":        "True if the condition is false.",
+	"Condition.tag":        "Tag the condition tests; without an operator the tag must equal value.",
+	"Condition.value":      "Value the tag must equal.",
+	"Condition.in":         "Values of which the tag must have one.",
+	"Condition.not_in":     "Values the tag must not have; also true when the tag is absent.",
+	"Condition.matches":    "Regular expression the tag value must match.",
+	"Condition.prefix":     "Prefix the tag value must start with.",
+	"Condition.exists":     "True if the tag is present.",
+	"Condition.not_exists": "True if the tag is absent.",
+
+	"TagConstraint.tag":                 "Tag whose value is checked.",
+	"TagConstraint.allowed_values":      "Values the tag may have. Items can reference value sets as $name.",
+	"TagConstraint.allowed_values_file": "File listing further allowed values, relative to the config file: a path, or an object with path, format and column.",
+	"TagConstraint.pattern":             "Regular expression the value must match.",
+	"TagConstraint.not_pattern":         "Regular expression the value must not match.",
+	"TagConstraint.min_length":          "Minimum length of the value in characters.",
+	"TagConstraint.max_length":          "Maximum length of the value in characters.",
+	"TagConstraint.case":                "Naming convention the value must follow.",
+	"TagConstraint.type":                "Kind of value the tag must hold.",
+	"TagConstraint.min":                 "Minimum value, for type integer.",
+	"TagConstraint.max":                 "Maximum value, for type integer.",
+	"TagConstraint.domains":             "Allowed email domains, for type email.",
+	"TagConstraint.schemes":             "Allowed URL schemes, for type url.",
+	"TagConstraint.formats":             "Accepted date formats using YYYY, MM and DD, or rfc3339, for types date and expiry; defaults to YYYY-MM-DD.",
+	"TagConstraint.max_days_ahead":      "Maximum number of days the date may lie in the future, for types date and expiry.",
+
+	"ValuesFile.path":   "Path of the file, relative to the config file.",
+	"ValuesFile.format": "Format of the file; detected from the extension if empty.",
+	"ValuesFile.column": "CSV header or JSON field holding the values; defaults to the first CSV column.",
+
+	"TagPattern.pattern": "Regular expression tag keys must match.",
+	"TagPattern.message": "Explanation shown when a tag key does not match.",
+
+	"Lookup.key":     "Tag whose value selects the rows of the table.",
+	"Lookup.columns": "Tags checked against the selected rows; defaults to every column except the key.",
+	"Lookup.rows":    "Inline rows of the table, combined with those from file.",
+	"Lookup.file":    "CSV or JSON file with further rows, relative to the config file: a path, or an object with path and format.",
+
+	"TableFile.path":   "Path of the file, relative to the config file.",
+	"TableFile.format": "Format of the file; detected from the extension if empty.",
+
+	"Global.always_required_tags":  "Tags every resource must have. Items can reference tag groups as $name.",
+	"Global.ignore_resource_types": "Resource types that are not validated, with the same patterns as resource_types.",
+	"Global.provider_limits":       "Cloud provider tag limits to enforce on resources of that provider.",
+	"Global.severity":              "Severity of always_required_tags and provider_limits violations; defaults to error.",
+	"Global.replace":               "Global lists that replace those of earlier configs instead of being appended to.",
+}
+
+// fieldEnums lists the allowed values of plain string fields
+var fieldEnums = map[string][]string{
+	"ValuesFile.format":      {FormatLines, FormatCSV, FormatJSON},
+	"TableFile.format":       {FormatCSV, FormatJSON},
+	"Global.provider_limits": providerNames,
+	"Global.replace":         mergeableLists,
+}
+
+// typeEnums lists the allowed values of string types
+var typeEnums = map[reflect.Type][]string{
+	reflect.TypeOf(Severity("")):  {string(SeverityError), string(SeverityWarning), string(SeverityInfo)},
+	reflect.TypeOf(CaseStyle("")): {string(CaseLower), string(CaseUpper), string(CaseKebab), string(CaseSnake), string(CasePascal), string(CaseCamel)},
+	reflect.TypeOf(ValueType("")): {string(TypeString), string(TypeEmail), string(TypeURL), string(TypeInteger), string(TypeBoolean), string(TypeDate), string(TypeExpiry), string(TypeSemver)},
+}
+
+// requiredFields lists the fields that must be set, by Go type
+var requiredFields = map[string][]string{
+	"Rule":          {"name"},
+	"TagConstraint": {"tag"},
+	"TagPattern":    {"pattern"},
+	"Lookup":        {"key"},
+	"ValuesFile":    {"path"},
+	"TableFile":     {"path"},
+}
+
+var yamlUnmarshaler = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+// Schema returns a JSON Schema for config files, generated from the Config type
+func Schema() *JSONSchema {
+	g := &schemaGenerator{definitions: make(map[string]*JSONSchema)}
+	root := g.object(reflect.TypeOf(Config{}))
+	root.Schema = SchemaURI
+	root.Title = "tftaglint configuration"
+	root.Definitions = g.definitions
+	return root
+}
+
+type schemaGenerator struct {
+	definitions map[string]*JSONSchema
+}
+
+// object returns the schema of a struct type
+func (g *schemaGenerator) object(t reflect.Type) *JSONSchema {
+	schema := &JSONSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*JSONSchema),
+		AdditionalProperties: false,
+		Required:             requiredFields[t.Name()],
+	}
+
+	fields := yamlFields(t)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		key := t.Name() + "." + name
+		property := g.typeSchema(fields[name].Type)
+		if enum, ok := fieldEnums[key]; ok {
+			if property.Items != nil {
+				property.Items.Enum = enum
+			} else {
+				property.Enum = enum
+			}
+		}
+		if property.Ref != "" {
+			// Keywords next to $ref are ignored in draft-07
+			property = &JSONSchema{AllOf: []*JSONSchema{property}}
+		}
+		property.Description = fieldDescriptions[key]
+		schema.Properties[name] = property
+	}
+
+	return schema
+}
+
+// typeSchema returns the schema of a field type. Structs are defined once and
+// referenced; those with a scalar shorthand also accept a string.
+func (g *schemaGenerator) typeSchema(t reflect.Type) *JSONSchema {
+	t = derefType(t)
+
+	if enum, ok := typeEnums[t]; ok {
+		return &JSONSchema{Type: "string", Enum: enum}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Slice:
+		return &JSONSchema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		if _, ok := g.definitions[t.Name()]; !ok {
+			g.definitions[t.Name()] = nil // Reserved for recursive types
+			g.definitions[t.Name()] = g.object(t)
+		}
+		ref := &JSONSchema{Ref: "#/definitions/" + t.Name()}
+		if reflect.PointerTo(t).Implements(yamlUnmarshaler) {
+			return &JSONSchema{AnyOf: []*JSONSchema{{Type: "string"}, ref}}
+		}
+		return ref
+	}
+
+	panic(fmt.Sprintf("no schema for config field type %s", t))
+}
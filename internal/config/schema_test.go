@@ -0,0 +1,78 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSchema_Descriptions(t *testing.T) {
+	schema := Schema()
+
+	described := make(map[string]bool)
+	check := func(typeName string, object *JSONSchema) {
+		for name, property := range object.Properties {
+			key := typeName + "." + name
+			described[key] = true
+			if property.Description == "" {
+				t.Errorf("Field %s has no description; add it to fieldDescriptions", key)
+			}
+		}
+	}
+	check("Config", schema)
+	for name, definition := range schema.Definitions {
+		check(name, definition)
+	}
+
+	for key := range fieldDescriptions {
+		if !described[key] {
+			t.Errorf("fieldDescriptions has %s, which is not a config field", key)
+		}
+	}
+}
+
+func TestSchema(t *testing.T) {
+	schema := Schema()
+
+	if schema.Schema != SchemaURI || schema.AdditionalProperties != false {
+		t.Errorf("Expected a closed draft-07 root schema, got $schema %q and additionalProperties %v", schema.Schema, schema.AdditionalProperties)
+	}
+
+	rules := schema.Properties["rules"]
+	if rules.Type != "array" || rules.Items.Ref != "#/definitions/Rule" {
+		t.Errorf("Expected rules to be an array of Rule, got %+v", rules)
+	}
+
+	rule := schema.Definitions["Rule"]
+	if !reflect.DeepEqual(rule.Required, []string{"name"}) {
+		t.Errorf("Expected Rule to require name, got %v", rule.Required)
+	}
+	if got := rule.Properties["severity"].Enum; !reflect.DeepEqual(got, []string{"error", "warning", "info"}) {
+		t.Errorf("Expected severity enum, got %v", got)
+	}
+	if got := rule.Properties["condition"].AllOf; len(got) != 1 || got[0].Ref != "#/definitions/Condition" {
+		t.Errorf("Expected condition to reference Condition, got %+v", rule.Properties["condition"])
+	}
+	if got := rule.Properties["required_any_of"]; got.Items.Type != "array" || got.Items.Items.Type != "string" {
+		t.Errorf("Expected required_any_of to be a list of lists of strings, got %+v", got)
+	}
+
+	condition := schema.Definitions["Condition"]
+	if got := condition.Properties["all"].Items.Ref; got != "#/definitions/Condition" {
+		t.Errorf("Expected all to reference Condition recursively, got %q", got)
+	}
+
+	valuesFile := schema.Definitions["TagConstraint"].Properties["allowed_values_file"]
+	if len(valuesFile.AnyOf) != 2 || valuesFile.AnyOf[0].Type != "string" || valuesFile.AnyOf[1].Ref != "#/definitions/ValuesFile" {
+		t.Errorf("Expected allowed_values_file to accept a path or an object, got %+v", valuesFile)
+	}
+
+	providers := schema.Definitions["Global"].Properties["provider_limits"]
+	if !reflect.DeepEqual(providers.Items.Enum, []string{"aws", "azure", "gcp"}) {
+		t.Errorf("Expected provider_limits enum, got %v", providers.Items.Enum)
+	}
+
+	if _, err := json.Marshal(schema); err != nil {
+		t.Errorf("json.Marshal() error = %v", err)
+	}
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+)
+
+// Severity is how serious a rule violation is
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// severityRanks orders severities from least to most severe
+var severityRanks = map[Severity]int{
+	SeverityInfo:    1,
+	SeverityWarning: 2,
+	SeverityError:   3,
+}
+
+// ParseSeverity converts a severity name into a Severity
+func ParseSeverity(s string) (Severity, error) {
+	severity := Severity(s)
+	if !severity.IsValid() {
+		return "", fmt.Errorf("invalid severity %q (use error, warning or info)", s)
+	}
+	return severity, nil
+}
+
+// IsValid reports whether s is a known severity; the empty severity is valid
+// and means error
+func (s Severity) IsValid() bool {
+	_, ok := severityRanks[s.OrDefault()]
+	return ok
+}
+
+// OrDefault returns the severity, or error when none is set
+func (s Severity) OrDefault() Severity {
+	if s == "" {
+		return SeverityError
+	}
+	return s
+}
+
+// AtLeast reports whether s is as severe as or more severe than min
+func (s Severity) AtLeast(min Severity) bool {
+	return severityRanks[s.OrDefault()] >= severityRanks[min.OrDefault()]
+}
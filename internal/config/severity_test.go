@@ -0,0 +1,51 @@
+package config
+
+import "testing"
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Severity
+		wantErr bool
+	}{
+		{input: "error", want: SeverityError},
+		{input: "warning", want: SeverityWarning},
+		{input: "info", want: SeverityInfo},
+		{input: "critical", wantErr: true},
+		{input: "Warning", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseSeverity(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSeverity(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSeverity(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeverity_AtLeast(t *testing.T) {
+	tests := []struct {
+		severity Severity
+		min      Severity
+		want     bool
+	}{
+		{SeverityError, SeverityError, true},
+		{SeverityWarning, SeverityError, false},
+		{SeverityInfo, SeverityWarning, false},
+		{SeverityWarning, SeverityWarning, true},
+		{SeverityError, SeverityInfo, true},
+		{"", SeverityError, true},
+		{SeverityWarning, "", false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.severity.AtLeast(tt.min); got != tt.want {
+			t.Errorf("%q.AtLeast(%q) = %v, want %v", tt.severity, tt.min, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,583 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError is a problem in a config file, reported with its position
+type ValidationError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+// ValidationErrors is the list of problems found in a config file
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// configValidator collects validation errors for a single config file
+type configValidator struct {
+	file       string
+	errors     ValidationErrors
+	valueFiles map[string]*ValueList          // Loaded values files by path, format and column
+	tableFiles map[string][]map[string]string // Loaded lookup files by path and format
+}
+
+// err returns the errors found, sorted by position, or nil
+func (cv *configValidator) err() error {
+	if len(cv.errors) == 0 {
+		return nil
+	}
+	sort.SliceStable(cv.errors, func(i, j int) bool {
+		a, b := cv.errors[i], cv.errors[j]
+		return a.Line < b.Line || (a.Line == b.Line && a.Column < b.Column)
+	})
+	return cv.errors
+}
+
+func (cv *configValidator) addError(node *yaml.Node, format string, args ...interface{}) {
+	e := ValidationError{
+		File:    cv.file,
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	}
+	// An anchored node is checked again wherever it is aliased; report it once
+	for _, existing := range cv.errors {
+		if existing == e {
+			return
+		}
+	}
+	cv.errors = append(cv.errors, e)
+}
+
+// checkKnownFields reports mapping keys that do not correspond to a yaml field
+// of the type being decoded into
+func (cv *configValidator) checkKnownFields(node *yaml.Node, t reflect.Type, context string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			cv.checkKnownFields(child, t, context)
+		}
+	case yaml.SequenceNode:
+		if t.Kind() == reflect.Slice {
+			for _, child := range node.Content {
+				cv.checkKnownFields(child, t.Elem(), context)
+			}
+		}
+	case yaml.MappingNode:
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Kind == yaml.ScalarNode && key.ShortTag() == "!!merge" {
+				// Merge keys bring in the keys of an aliased mapping, or of a
+				// sequence of them
+				merged := []*yaml.Node{value}
+				if value.Kind == yaml.SequenceNode {
+					merged = value.Content
+				}
+				for _, m := range merged {
+					cv.checkKnownFields(m, t, context)
+				}
+				continue
+			}
+			field, ok := fields[key.Value]
+			if !ok {
+				cv.addError(key, "unknown field %q in %s%s", key.Value, context, suggestion(key.Value, fieldNames(fields)))
+				continue
+			}
+			cv.checkKnownFields(value, field.Type, key.Value)
+		}
+	case yaml.AliasNode:
+		cv.checkKnownFields(node.Alias, t, context)
+	}
+}
+
+// yamlFields returns the struct fields of t keyed by their yaml name
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+func fieldNames(fields map[string]reflect.StructField) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// suggestion returns a "did you mean" hint for the candidate closest to value
+func suggestion(value string, candidates []string) string {
+	best := ClosestMatch(value, candidates)
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+// ClosestMatch returns the candidate with the smallest edit distance to value,
+// or an empty string if none is close enough to be a likely typo
+func ClosestMatch(value string, candidates []string) string {
+	best := ""
+	bestDistance := len(value)/3 + 1
+	for _, candidate := range candidates {
+		if d := levenshtein(value, candidate); d <= bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
+
+// checkRules validates the semantics of each rule, using the rule nodes for positions
+func (cv *configValidator) checkRules(config *Config, root *yaml.Node) {
+	ruleNodes := sequenceItems(mappingValue(documentContent(root), "rules"))
+	seen := make(map[string]int)
+
+	for i := range config.Rules {
+		rule := &config.Rules[i]
+		node := &yaml.Node{}
+		if i < len(ruleNodes) {
+			node = ruleNodes[i]
+		}
+
+		if strings.TrimSpace(rule.Name) == "" {
+			cv.addError(node, "rule has an empty name")
+		} else if firstLine, ok := seen[rule.Name]; ok {
+			cv.addError(nodeOr(mappingValue(node, "name"), node), "duplicate rule name %q (first defined on line %d)", rule.Name, firstLine)
+		} else {
+			seen[rule.Name] = node.Line
+		}
+
+		rule.origin = ValidationError{File: cv.file, Line: node.Line, Column: node.Column}
+
+		// A disabled rule only names the rule it removes
+		if !rule.Disabled && !rule.hasChecks() {
+			cv.addError(node, "rule %q has no checks", rule.Name)
+		}
+
+		if !rule.Severity.IsValid() {
+			cv.addError(nodeOr(mappingValue(node, "severity"), node), "invalid severity %q in rule %q (use error, warning or info)", rule.Severity, rule.Name)
+		}
+
+		if rule.Condition != nil {
+			cv.checkCondition(rule.Condition, nodeOr(mappingValue(node, "condition"), node), rule.Name)
+		}
+
+		cv.checkResourceTypes(rule.ResourceTypes, mappingValue(node, "resource_types"), fmt.Sprintf("resource_types of rule %q", rule.Name))
+		cv.checkResourceTypes(rule.ExcludeResourceTypes, mappingValue(node, "exclude_resource_types"), fmt.Sprintf("exclude_resource_types of rule %q", rule.Name))
+
+		cv.checkTagGroups(rule.RequiredAnyOf, mappingValue(node, "required_any_of"), node, "required_any_of", rule.Name)
+		cv.checkTagGroups(rule.RequiredOneOf, mappingValue(node, "required_one_of"), node, "required_one_of", rule.Name)
+		cv.checkTagGroups(rule.MutuallyExclusive, mappingValue(node, "mutually_exclusive"), node, "mutually_exclusive", rule.Name)
+
+		for j := range rule.Dependencies {
+			cv.checkDependency(&rule.Dependencies[j], listItem(mappingValue(node, "dependencies"), j, node), rule.Name)
+		}
+
+		if rule.Lookup != nil {
+			cv.checkLookup(rule.Lookup, nodeOr(mappingValue(node, "lookup"), node), rule.Name)
+		}
+
+		constraintNodes := sequenceItems(mappingValue(node, "tag_constraints"))
+		for j := range rule.TagConstraints {
+			constraintNode := node
+			if j < len(constraintNodes) {
+				constraintNode = constraintNodes[j]
+			}
+			cv.checkConstraint(&rule.TagConstraints[j], constraintNode, rule.Name)
+		}
+	}
+}
+
+// checkConstraint validates a tag constraint and compiles its patterns
+func (cv *configValidator) checkConstraint(constraint *TagConstraint, node *yaml.Node, ruleName string) {
+	if constraint.Tag == "" {
+		cv.addError(node, "tag constraint in rule %q has no tag", ruleName)
+	}
+
+	if allowedNode := mappingValue(node, "allowed_values"); allowedNode != nil && len(constraint.AllowedValues) == 0 {
+		cv.addError(allowedNode, "tag constraint for %q in rule %q has no allowed_values", constraint.Tag, ruleName)
+	} else if !constraint.hasChecks() {
+		cv.addError(node, "tag constraint for %q in rule %q has no checks", constraint.Tag, ruleName)
+	}
+
+	if constraint.AllowedValuesFile != nil {
+		cv.loadValuesFile(constraint.AllowedValuesFile, nodeOr(mappingValue(node, "allowed_values_file"), node), ruleName)
+	}
+
+	if constraint.MinLength < 0 {
+		cv.addError(nodeOr(mappingValue(node, "min_length"), node), "min_length for %q in rule %q must not be negative", constraint.Tag, ruleName)
+	}
+	if constraint.MaxLength < 0 {
+		cv.addError(nodeOr(mappingValue(node, "max_length"), node), "max_length for %q in rule %q must not be negative", constraint.Tag, ruleName)
+	}
+	if constraint.MaxLength > 0 && constraint.MinLength > constraint.MaxLength {
+		cv.addError(nodeOr(mappingValue(node, "min_length"), node), "min_length %d for %q in rule %q is greater than max_length %d", constraint.MinLength, constraint.Tag, ruleName, constraint.MaxLength)
+	}
+
+	if !constraint.Case.IsValid() {
+		cv.addError(nodeOr(mappingValue(node, "case"), node), "invalid case %q for %q in rule %q (use %s)", constraint.Case, constraint.Tag, ruleName, caseStyleNames)
+	}
+
+	cv.checkValueType(constraint, node, ruleName)
+
+	constraint.PatternRegex = cv.compilePattern(constraint.Pattern, nodeOr(mappingValue(node, "pattern"), node), ruleName)
+	constraint.NotPatternRegex = cv.compilePattern(constraint.NotPattern, nodeOr(mappingValue(node, "not_pattern"), node), ruleName)
+}
+
+// checkValueType validates the type of a tag constraint and that its type
+// options belong to that type
+func (cv *configValidator) checkValueType(constraint *TagConstraint, node *yaml.Node, ruleName string) {
+	if !constraint.Type.IsValid() {
+		cv.addError(nodeOr(mappingValue(node, "type"), node), "invalid type %q for %q in rule %q (use %s)", constraint.Type, constraint.Tag, ruleName, valueTypeNames)
+		return
+	}
+
+	options := []struct {
+		key   string
+		set   bool
+		types []ValueType
+	}{
+		{"min", constraint.Min != nil, []ValueType{TypeInteger}},
+		{"max", constraint.Max != nil, []ValueType{TypeInteger}},
+		{"domains", len(constraint.Domains) > 0, []ValueType{TypeEmail}},
+		{"schemes", len(constraint.Schemes) > 0, []ValueType{TypeURL}},
+		{"formats", len(constraint.Formats) > 0, []ValueType{TypeDate, TypeExpiry}},
+		{"max_days_ahead", constraint.MaxDaysAhead != nil, []ValueType{TypeDate, TypeExpiry}},
+	}
+	for _, option := range options {
+		if option.set && !slices.Contains(option.types, constraint.Type) {
+			names := make([]string, len(option.types))
+			for i, t := range option.types {
+				names[i] = string(t)
+			}
+			cv.addError(nodeOr(mappingValue(node, option.key), node), "%s for %q in rule %q requires type %s", option.key, constraint.Tag, ruleName, strings.Join(names, " or "))
+		}
+	}
+
+	formatNodes := sequenceItems(mappingValue(node, "formats"))
+	for i, format := range constraint.Formats {
+		if _, ok := DateLayout(format); !ok {
+			formatNode := nodeOr(mappingValue(node, "formats"), node)
+			if i < len(formatNodes) {
+				formatNode = formatNodes[i]
+			}
+			cv.addError(formatNode, "invalid date format %q for %q in rule %q (use YYYY, MM and DD, or rfc3339)", format, constraint.Tag, ruleName)
+		}
+	}
+
+	if constraint.MaxDaysAhead != nil && *constraint.MaxDaysAhead < 0 {
+		cv.addError(nodeOr(mappingValue(node, "max_days_ahead"), node), "max_days_ahead for %q in rule %q must not be negative", constraint.Tag, ruleName)
+	}
+
+	if constraint.Min != nil && constraint.Max != nil && *constraint.Min > *constraint.Max {
+		cv.addError(nodeOr(mappingValue(node, "min"), node), "min %d for %q in rule %q is greater than max %d", *constraint.Min, constraint.Tag, ruleName, *constraint.Max)
+	}
+}
+
+// compilePattern compiles a regex from the config, reporting an error at node
+// if it is invalid. An empty pattern compiles to nil.
+func (cv *configValidator) compilePattern(pattern string, node *yaml.Node, ruleName string) *regexp.Regexp {
+	if pattern == "" {
+		return nil
+	}
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		cv.addError(node, "invalid regex pattern in rule %s: %v", ruleName, err)
+		return nil
+	}
+	return regex
+}
+
+// hasChecks reports whether the constraint checks anything
+func (c *TagConstraint) hasChecks() bool {
+	return len(c.AllowedValues) > 0 ||
+		c.AllowedValuesFile != nil ||
+		c.Pattern != "" ||
+		c.NotPattern != "" ||
+		c.MinLength > 0 ||
+		c.MaxLength > 0 ||
+		c.Case != "" ||
+		c.Type != ""
+}
+
+// checkCondition validates a condition and its nested conditions and compiles
+// their matches patterns
+func (cv *configValidator) checkCondition(condition *Condition, node *yaml.Node, ruleName string) {
+	var kinds []string
+	if condition.All != nil {
+		kinds = append(kinds, "all")
+	}
+	if condition.Any != nil {
+		kinds = append(kinds, "any")
+	}
+	if condition.Not != nil {
+		kinds = append(kinds, "not")
+	}
+	operators := condition.operators()
+	if condition.Tag != "" || len(operators) > 0 {
+		kinds = append(kinds, "tag")
+	}
+
+	switch {
+	case len(kinds) > 1:
+		cv.addError(node, "condition in rule %q combines %s; use only one of all, any, not or tag", ruleName, strings.Join(kinds, ", "))
+		return
+	case len(kinds) == 0 || kinds[0] == "tag" && condition.Tag == "":
+		cv.addError(node, "condition in rule %q has no tag", ruleName)
+		return
+	}
+
+	switch kinds[0] {
+	case "all", "any":
+		conditions := condition.All
+		if kinds[0] == "any" {
+			conditions = condition.Any
+		}
+		listNode := nodeOr(mappingValue(node, kinds[0]), node)
+		if len(conditions) == 0 {
+			cv.addError(listNode, "%s in rule %q has no conditions", kinds[0], ruleName)
+		}
+		itemNodes := sequenceItems(listNode)
+		for i := range conditions {
+			itemNode := listNode
+			if i < len(itemNodes) {
+				itemNode = itemNodes[i]
+			}
+			cv.checkCondition(&conditions[i], itemNode, ruleName)
+		}
+	case "not":
+		cv.checkCondition(condition.Not, nodeOr(mappingValue(node, "not"), node), ruleName)
+	case "tag":
+		if len(operators) > 1 {
+			cv.addError(node, "condition on tag %q in rule %q uses several operators (%s)", condition.Tag, ruleName, strings.Join(operators, ", "))
+		}
+		if condition.Matches != "" {
+			regex, err := regexp.Compile(condition.Matches)
+			if err != nil {
+				cv.addError(nodeOr(mappingValue(node, "matches"), node), "invalid matches pattern in rule %s: %v", ruleName, err)
+				return
+			}
+			condition.MatchesRegex = regex
+		}
+	}
+}
+
+// operators returns the names of the operators set on a tag condition
+func (c *Condition) operators() []string {
+	var operators []string
+	if c.Value != "" {
+		operators = append(operators, "value")
+	}
+	if len(c.In) > 0 {
+		operators = append(operators, "in")
+	}
+	if len(c.NotIn) > 0 {
+		operators = append(operators, "not_in")
+	}
+	if c.Matches != "" {
+		operators = append(operators, "matches")
+	}
+	if c.Prefix != "" {
+		operators = append(operators, "prefix")
+	}
+	if c.Exists {
+		operators = append(operators, "exists")
+	}
+	if c.NotExists {
+		operators = append(operators, "not_exists")
+	}
+	return operators
+}
+
+// checkGlobal validates the global section
+func (cv *configValidator) checkGlobal(config *Config, root *yaml.Node) {
+	globalNode := mappingValue(documentContent(root), "global")
+	if !config.Global.Severity.IsValid() {
+		node := mappingValue(globalNode, "severity")
+		cv.addError(nodeOr(node, &yaml.Node{}), "invalid severity %q in global (use error, warning or info)", config.Global.Severity)
+	}
+	cv.checkResourceTypes(config.Global.IgnoreResourceTypes, mappingValue(globalNode, "ignore_resource_types"), "ignore_resource_types")
+
+	providerNodes := sequenceItems(mappingValue(globalNode, "provider_limits"))
+	for i, name := range config.Global.ProviderLimits {
+		if _, ok := LookupProviderLimits(name); !ok {
+			node := &yaml.Node{}
+			if i < len(providerNodes) {
+				node = providerNodes[i]
+			}
+			cv.addError(node, "unknown provider %q in provider_limits (use %s)", name, strings.Join(providerNames, ", "))
+		}
+	}
+
+	replaceNodes := sequenceItems(mappingValue(globalNode, "replace"))
+	for i, name := range config.Global.Replace {
+		if !slices.Contains(mergeableLists, name) {
+			node := &yaml.Node{}
+			if i < len(replaceNodes) {
+				node = replaceNodes[i]
+			}
+			cv.addError(node, "unknown list %q in global replace (use %s)", name, strings.Join(mergeableLists, ", "))
+		}
+	}
+}
+
+// checkResourceTypes validates a list of resource type patterns
+func (cv *configValidator) checkResourceTypes(patterns []string, listNode *yaml.Node, context string) {
+	itemNodes := sequenceItems(listNode)
+	for i, pattern := range patterns {
+		if err := validateResourceType(pattern); err != nil {
+			node := nodeOr(listNode, &yaml.Node{})
+			if i < len(itemNodes) {
+				node = itemNodes[i]
+			}
+			cv.addError(node, "invalid resource type pattern %q in %s: %v", pattern, context, err)
+		}
+	}
+}
+
+// checkDependency reports dependencies without exactly one trigger tag or
+// without required tags
+func (cv *configValidator) checkDependency(dependency *Dependency, node *yaml.Node, ruleName string) {
+	switch {
+	case dependency.WhenPresent == "" && dependency.WhenAbsent == "":
+		cv.addError(node, "dependency in rule %q has no when_present or when_absent", ruleName)
+		return
+	case dependency.WhenPresent != "" && dependency.WhenAbsent != "":
+		cv.addError(node, "dependency in rule %q uses both when_present and when_absent", ruleName)
+		return
+	}
+
+	trigger := dependency.Trigger()
+	if dependency.Value != "" && dependency.WhenPresent == "" {
+		cv.addError(nodeOr(mappingValue(node, "value"), node), "value in dependency on %q in rule %q requires when_present", trigger, ruleName)
+	}
+	if len(dependency.Require) == 0 {
+		cv.addError(node, "dependency on %q in rule %q has no require", trigger, ruleName)
+	}
+}
+
+// checkTagGroups reports groups of required_any_of, required_one_of or
+// mutually_exclusive with fewer than two tags. Groups with tag group
+// references are checked once expanded.
+func (cv *configValidator) checkTagGroups(groups [][]string, listNode, ruleNode *yaml.Node, key, ruleName string) {
+	for i, group := range groups {
+		if slices.ContainsFunc(group, isReference) {
+			continue
+		}
+		if len(group) < 2 {
+			cv.addError(listItem(listNode, i, ruleNode), "%s group in rule %q needs at least two tags", key, ruleName)
+		}
+	}
+}
+
+// hasChecks reports whether the rule checks anything
+func (r *Rule) hasChecks() bool {
+	return len(r.RequiredTags) > 0 ||
+		len(r.ForbiddenTags) > 0 ||
+		len(r.RequiredAnyOf) > 0 ||
+		len(r.RequiredOneOf) > 0 ||
+		len(r.MutuallyExclusive) > 0 ||
+		len(r.Dependencies) > 0 ||
+		len(r.TagConstraints) > 0 ||
+		len(r.TagPatterns) > 0 ||
+		len(r.ProtectedTags) > 0 ||
+		r.Lookup != nil
+}
+
+// documentContent returns the top-level node of a parsed document
+func documentContent(node *yaml.Node) *yaml.Node {
+	if node != nil && node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return node.Content[0]
+	}
+	return node
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// sequenceItems returns the items of a sequence node, or nil
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return node.Content
+}
+
+func nodeOr(node, fallback *yaml.Node) *yaml.Node {
+	if node != nil {
+		return node
+	}
+	return fallback
+}
@@ -0,0 +1,370 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantErrors []string
+	}{
+		{
+			name: "unknown rule field with suggestion",
+			content: `rules:
+  - name: owner
+    required_tag:
+      - Owner
+`,
+			wantErrors: []string{
+				`:3:5: unknown field "required_tag" in rules (did you mean "required_tags"?)`,
+				`:2:5: rule "owner" has no checks`,
+			},
+		},
+		{
+			name: "unknown nested field",
+			content: `rules:
+  - name: env
+    tag_constraints:
+      - tag: Environment
+        alowed_values: [dev, prod]
+`,
+			wantErrors: []string{
+				`:5:9: unknown field "alowed_values" in tag_constraints (did you mean "allowed_values"?)`,
+				`:4:9: tag constraint for "Environment" in rule "env" has no checks`,
+			},
+		},
+		{
+			name: "unknown top-level and global fields",
+			content: `rule:
+  - name: x
+global:
+  ignore_resource_type: [aws_iam_role]
+`,
+			wantErrors: []string{
+				`:1:1: unknown field "rule" in config (did you mean "rules"?)`,
+				`:4:3: unknown field "ignore_resource_type" in global (did you mean "ignore_resource_types"?)`,
+			},
+		},
+		{
+			name: "unknown field without close match",
+			content: `rules:
+  - name: x
+    required_tags: [A]
+    severity_level: high
+`,
+			wantErrors: []string{`:4:5: unknown field "severity_level" in rules`},
+		},
+		{
+			name: "unknown field in merged anchor reported once",
+			content: `rules:
+  - &base
+    name: base
+    required_tags: [Owner]
+    severity_level: high
+  - <<: *base
+    name: r1
+  - <<: [*base]
+    name: r2
+`,
+			wantErrors: []string{`:5:5: unknown field "severity_level" in rules`},
+		},
+		{
+			name: "empty and duplicate rule names",
+			content: `rules:
+  - name: owner
+    required_tags: [Owner]
+  - name: ""
+    required_tags: [Name]
+  - name: owner
+    forbidden_tags: [Temp]
+`,
+			wantErrors: []string{
+				`:4:5: rule has an empty name`,
+				`:6:11: duplicate rule name "owner" (first defined on line 2)`,
+			},
+		},
+		{
+			name: "condition without a tag",
+			content: `rules:
+  - name: prod
+    condition:
+      value: production
+    required_tags: [Owner]
+`,
+			wantErrors: []string{`:4:7: condition in rule "prod" has no tag`},
+		},
+		{
+			name: "invalid value constraints",
+			content: `rules:
+  - name: cost
+    tag_constraints:
+      - tag: CostCenter
+        allowed_values: []
+      - tag: Team
+        pattern: "[invalid"
+        case: Kebab
+      - tag: Name
+        min_length: 10
+        max_length: 5
+`,
+			wantErrors: []string{
+				`:5:25: tag constraint for "CostCenter" in rule "cost" has no allowed_values`,
+				`:7:18: invalid regex pattern in rule cost`,
+				`:8:15: invalid case "Kebab" for "Team" in rule "cost" (use lowercase, uppercase, kebab-case, snake_case, PascalCase or camelCase)`,
+				`:10:21: min_length 10 for "Name" in rule "cost" is greater than max_length 5`,
+			},
+		},
+		{
+			name: "invalid value types",
+			content: `rules:
+  - name: owner
+    tag_constraints:
+      - tag: Owner
+        type: mail
+      - tag: Retention
+        type: integer
+        min: 30
+        max: 7
+        domains: [example.com]
+`,
+			wantErrors: []string{
+				`:5:15: invalid type "mail" for "Owner" in rule "owner" (use string, email, url, integer, boolean, date, expiry or semver)`,
+				`:8:14: min 30 for "Retention" in rule "owner" is greater than max 7`,
+				`:10:18: domains for "Retention" in rule "owner" requires type email`,
+			},
+		},
+		{
+			name: "invalid date options",
+			content: `rules:
+  - name: sandbox
+    tag_constraints:
+      - tag: ExpiresOn
+        type: expiry
+        formats: [YYYY-MM-DD, MM/YYYY]
+        max_days_ahead: -1
+      - tag: Owner
+        type: email
+        max_days_ahead: 30
+`,
+			wantErrors: []string{
+				`:6:31: invalid date format "MM/YYYY" for "ExpiresOn" in rule "sandbox" (use YYYY, MM and DD, or rfc3339)`,
+				`:7:25: max_days_ahead for "ExpiresOn" in rule "sandbox" must not be negative`,
+				`:10:25: max_days_ahead for "Owner" in rule "sandbox" requires type date or expiry`,
+			},
+		},
+		{
+			name: "invalid resource type patterns",
+			content: `rules:
+  - name: aws
+    required_tags: [Owner]
+    resource_types: ["aws_[s3"]
+    exclude_resource_types: ["/aws_(iam/"]
+global:
+  ignore_resource_types:
+    - data.
+`,
+			wantErrors: []string{
+				`:4:22: invalid resource type pattern "aws_[s3" in resource_types of rule "aws": syntax error in pattern`,
+				`:5:30: invalid resource type pattern "/aws_(iam/" in exclude_resource_types of rule "aws"`,
+				`:8:7: invalid resource type pattern "data." in ignore_resource_types: empty pattern`,
+			},
+		},
+		{
+			name: "tag groups with fewer than two tags",
+			content: `rules:
+  - name: owner
+    required_any_of: [[Owner, Team]]
+    required_one_of:
+      - [CostCenter]
+    mutually_exclusive: [[]]
+`,
+			wantErrors: []string{
+				`:5:9: required_one_of group in rule "owner" needs at least two tags`,
+				`:6:26: mutually_exclusive group in rule "owner" needs at least two tags`,
+			},
+		},
+		{
+			name: "invalid dependencies",
+			content: `rules:
+  - name: backup
+    dependencies:
+      - require: [BackupSchedule]
+      - when_present: BackupRequired
+        when_absent: Backup
+        require: [BackupSchedule]
+      - when_absent: Owner
+        value: "true"
+      - when_present: BackupRequired
+        require: [BackupSchedule]
+`,
+			wantErrors: []string{
+				`:4:9: dependency in rule "backup" has no when_present or when_absent`,
+				`:5:9: dependency in rule "backup" uses both when_present and when_absent`,
+				`:8:9: dependency on "Owner" in rule "backup" has no require`,
+				`:9:16: value in dependency on "Owner" in rule "backup" requires when_present`,
+			},
+		},
+		{
+			name: "unknown provider limits",
+			content: `global:
+  provider_limits: [aws, gpc]
+`,
+			wantErrors: []string{
+				`:2:26: unknown provider "gpc" in provider_limits (use aws, azure, gcp)`,
+			},
+		},
+		{
+			name: "invalid combined conditions",
+			content: `rules:
+  - name: backup
+    condition:
+      all:
+        - tag: Environment
+          in: [staging, production]
+          prefix: prod
+        - any: []
+        - not:
+            tag: DataClass
+            all: []
+        - matches: "^x"
+        - tag: Team
+          matches: "[invalid"
+    required_tags: [BackupPolicy]
+`,
+			wantErrors: []string{
+				`:5:11: condition on tag "Environment" in rule "backup" uses several operators (in, prefix)`,
+				`:8:16: any in rule "backup" has no conditions`,
+				`:10:13: condition in rule "backup" combines all, tag; use only one of all, any, not or tag`,
+				`:12:11: condition in rule "backup" has no tag`,
+				`:14:20: invalid matches pattern in rule backup`,
+			},
+		},
+		{
+			name: "invalid regex with position",
+			content: `rules:
+  - name: naming
+    tag_patterns:
+      - pattern: "[invalid"
+`,
+			wantErrors: []string{`:4:18: invalid regex pattern in rule naming`},
+		},
+		{
+			name: "invalid severities",
+			content: `rules:
+  - name: owner
+    required_tags: [Owner]
+    severity: critical
+global:
+  always_required_tags: [Name]
+  severity: low
+`,
+			wantErrors: []string{
+				`:4:15: invalid severity "critical" in rule "owner" (use error, warning or info)`,
+				`:7:13: invalid severity "low" in global (use error, warning or info)`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := filepath.Join(t.TempDir(), "tag-rules.yaml")
+			if err := os.WriteFile(tmpFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to create temp file: %v", err)
+			}
+
+			_, err := LoadConfig(tmpFile)
+
+			var validationErrors ValidationErrors
+			if !errors.As(err, &validationErrors) {
+				t.Fatalf("Expected ValidationErrors, got %v", err)
+			}
+			if len(validationErrors) != len(tt.wantErrors) {
+				t.Errorf("Expected %d errors, got %d:\n%v", len(tt.wantErrors), len(validationErrors), err)
+			}
+			for _, want := range tt.wantErrors {
+				if !strings.Contains(err.Error(), tmpFile+want) {
+					t.Errorf("Expected error containing %q, got:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadConfig_MergeKeys(t *testing.T) {
+	content := `rules:
+  - &base
+    name: base
+    resource_types: [aws_instance]
+    required_tags: [Owner]
+  - <<: *base
+    name: r1
+    required_tags: [Owner, Team]
+`
+	tmpFile := filepath.Join(t.TempDir(), "tag-rules.yaml")
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+
+	config, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if len(config.Rules) != 2 {
+		t.Fatalf("Expected 2 rules, got %d", len(config.Rules))
+	}
+	r1 := config.Rules[1]
+	if r1.Name != "r1" || !reflect.DeepEqual(r1.ResourceTypes, []string{"aws_instance"}) || !reflect.DeepEqual(r1.RequiredTags, []string{"Owner", "Team"}) {
+		t.Errorf("Unexpected merged rule: %+v", r1)
+	}
+}
+
+func TestLoadConfig_ValidationErrorsSorted(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "tag-rules.yaml")
+	content := `rules:
+  - name: a
+  - name: b
+    required_tag: [X]
+`
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create temp file: %v", err)
+	}
+
+	_, err := LoadConfig(tmpFile)
+	var validationErrors ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		t.Fatalf("Expected ValidationErrors, got %v", err)
+	}
+	for i := 1; i < len(validationErrors); i++ {
+		if validationErrors[i].Line < validationErrors[i-1].Line {
+			t.Errorf("Errors not sorted by line:\n%v", err)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"required_tag", "required_tags", 1},
+		{"alowed_values", "allowed_values", 1},
+		{"kitten", "sitting", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			if got := levenshtein(tt.a, tt.b); got != tt.want {
+				t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package config
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Value file formats
+const (
+	FormatLines = "lines" // One value per line; blank lines and # comments are skipped
+	FormatCSV   = "csv"   // A header row followed by records
+	FormatJSON  = "json"  // An array of strings, or of objects with a column field
+)
+
+// ValuesFile is a file listing allowed values. It is written either as a path
+// or as a mapping with the path and how to read it.
+type ValuesFile struct {
+	Path   string `yaml:"path,omitempty"`   // Relative to the config file
+	Format string `yaml:"format,omitempty"` // Detected from the file extension if empty
+	Column string `yaml:"column,omitempty"` // CSV header or JSON field; defaults to the first CSV column
+
+	Values *ValueList `yaml:"-"`
+}
+
+func (f *ValuesFile) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		f.Path = node.Value
+		return nil
+	}
+	type plain ValuesFile
+	return node.Decode((*plain)(f))
+}
+
+// Contains reports whether the loaded file lists value
+func (f *ValuesFile) Contains(value string) bool {
+	return f != nil && f.Values.Contains(value)
+}
+
+// ValueList is a list of values with constant-time lookup
+type ValueList struct {
+	Values []string
+	set    map[string]bool
+}
+
+// NewValueList creates a ValueList from values
+func NewValueList(values []string) *ValueList {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return &ValueList{Values: values, set: set}
+}
+
+// Contains reports whether value is in the list
+func (l *ValueList) Contains(value string) bool {
+	return l != nil && l.set[value]
+}
+
+// fileFormat returns the format of a data file, detecting it from the
+// extension when it is not set
+func fileFormat(path, format string) string {
+	if format != "" {
+		return format
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV
+	case ".json":
+		return FormatJSON
+	default:
+		return FormatLines
+	}
+}
+
+// resolvePath resolves a path from the config relative to the config file
+func (cv *configValidator) resolvePath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(cv.file), path)
+}
+
+// loadValuesFile reads a values file relative to the config file. Files are
+// cached so that rules sharing a file read and store it only once.
+func (cv *configValidator) loadValuesFile(file *ValuesFile, node *yaml.Node, ruleName string) {
+	format := fileFormat(file.Path, file.Format)
+	switch format {
+	case FormatLines, FormatCSV, FormatJSON:
+	default:
+		cv.addError(nodeOr(mappingValue(node, "format"), node), "invalid format %q for values file in rule %q (use lines, csv or json)", format, ruleName)
+		return
+	}
+	if file.Path == "" {
+		cv.addError(node, "values file in rule %q has no path", ruleName)
+		return
+	}
+	if file.Column != "" && format == FormatLines {
+		cv.addError(nodeOr(mappingValue(node, "column"), node), "column for values file %s in rule %q requires csv or json format", file.Path, ruleName)
+		return
+	}
+
+	path := cv.resolvePath(file.Path)
+	key := strings.Join([]string{path, format, file.Column}, "\x00")
+	if values, ok := cv.valueFiles[key]; ok {
+		file.Values = values
+		return
+	}
+
+	values, err := readValuesFile(path, format, file.Column)
+	if err == nil && len(values) == 0 {
+		err = fmt.Errorf("no values found")
+	}
+	if err != nil {
+		cv.addError(nodeOr(mappingValue(node, "path"), node), "failed to load values file %s in rule %q: %v", file.Path, ruleName, err)
+		return
+	}
+
+	if cv.valueFiles == nil {
+		cv.valueFiles = make(map[string]*ValueList)
+	}
+	file.Values = NewValueList(values)
+	cv.valueFiles[key] = file.Values
+}
+
+// readValuesFile reads the values listed in a file of the given format
+func readValuesFile(path, format, column string) ([]string, error) {
+	content, err := readDataFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatCSV:
+		return readCSVValues(content, column)
+	case FormatJSON:
+		return readJSONValues(content, column)
+	default:
+		var values []string
+		for _, line := range strings.Split(content, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				values = append(values, line)
+			}
+		}
+		return values, nil
+	}
+}
+
+// readDataFile reads a values or table file as text
+func readDataFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	// Spreadsheet exports often start with a byte order mark
+	return strings.TrimPrefix(string(data), "\ufeff"), nil
+}
+
+func readCSVValues(content, column string) ([]string, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	index := 0
+	if column != "" {
+		index = -1
+		for i, name := range records[0] {
+			if strings.TrimSpace(name) == column {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("column %q not found in header (columns: %s)", column, strings.Join(records[0], ", "))
+		}
+	}
+
+	var values []string
+	for _, record := range records[1:] {
+		if index < len(record) {
+			if value := strings.TrimSpace(record[index]); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values, nil
+}
+
+func readJSONValues(content, column string) ([]string, error) {
+	var items []interface{}
+	if err := json.Unmarshal([]byte(content), &items); err != nil {
+		return nil, fmt.Errorf("expected a JSON array: %w", err)
+	}
+
+	values := make([]string, 0, len(items))
+	for i, item := range items {
+		if object, ok := item.(map[string]interface{}); ok && column != "" {
+			item = object[column]
+		}
+		value, ok := item.(string)
+		if !ok {
+			if column != "" {
+				return nil, fmt.Errorf("item %d has no string field %q", i, column)
+			}
+			return nil, fmt.Errorf("item %d is not a string", i)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
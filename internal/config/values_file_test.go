@@ -0,0 +1,190 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig_AllowedValuesFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		constraint string
+		want       []string
+	}{
+		{
+			name:       "newline list",
+			files:      map[string]string{"teams.txt": "# Teams\nplatform\n\n  data  \n"},
+			constraint: `allowed_values_file: teams.txt`,
+			want:       []string{"platform", "data"},
+		},
+		{
+			name:  "csv with column and byte order mark",
+			files: map[string]string{"finance/cost-centers.csv": "\ufeffname,code\nPlatform,CC-10001\nData,CC-10002\n"},
+			constraint: `allowed_values_file:
+          path: finance/cost-centers.csv
+          column: code`,
+			want: []string{"CC-10001", "CC-10002"},
+		},
+		{
+			name:       "csv defaults to first column",
+			files:      map[string]string{"codes.csv": "code,name\nCC-1,A\nCC-2,B\n"},
+			constraint: `allowed_values_file: codes.csv`,
+			want:       []string{"CC-1", "CC-2"},
+		},
+		{
+			name:       "json array",
+			files:      map[string]string{"envs.json": `["dev", "prod"]`},
+			constraint: `allowed_values_file: envs.json`,
+			want:       []string{"dev", "prod"},
+		},
+		{
+			name:  "json objects with column",
+			files: map[string]string{"envs.data": `[{"name": "dev"}, {"name": "prod"}]`},
+			constraint: `allowed_values_file:
+          path: envs.data
+          format: json
+          column: name`,
+			want: []string{"dev", "prod"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("Failed to create directory: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write values file: %v", err)
+				}
+			}
+
+			configPath := filepath.Join(dir, "tag-rules.yaml")
+			content := `rules:
+  - name: values
+    tag_constraints:
+      - tag: Value
+        ` + tt.constraint + "\n"
+			if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			config, err := LoadConfig(configPath)
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			file := config.Rules[0].TagConstraints[0].AllowedValuesFile
+			if file == nil || file.Values == nil {
+				t.Fatalf("Expected values to be loaded, got %+v", file)
+			}
+			if !reflect.DeepEqual(file.Values.Values, tt.want) {
+				t.Errorf("Expected values %v, got %v", tt.want, file.Values.Values)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_AllowedValuesFileCached(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "teams.txt"), []byte("platform\ndata\n"), 0644); err != nil {
+		t.Fatalf("Failed to write values file: %v", err)
+	}
+	configPath := filepath.Join(dir, "tag-rules.yaml")
+	content := `rules:
+  - name: a
+    tag_constraints:
+      - tag: Team
+        allowed_values_file: teams.txt
+  - name: b
+    tag_constraints:
+      - tag: OwnerTeam
+        allowed_values_file: ./teams.txt
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	a := config.Rules[0].TagConstraints[0].AllowedValuesFile.Values
+	b := config.Rules[1].TagConstraints[0].AllowedValuesFile.Values
+	if a == nil || a != b {
+		t.Errorf("Expected rules to share the loaded values, got %p and %p", a, b)
+	}
+}
+
+func TestLoadConfig_AllowedValuesFileErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		constraint string
+		wantError  string
+	}{
+		{
+			name:       "missing file",
+			constraint: `allowed_values_file: missing.txt`,
+			wantError:  `:5:30: failed to load values file missing.txt in rule "values"`,
+		},
+		{
+			name:  "unknown csv column",
+			files: map[string]string{"codes.csv": "code,name\nCC-1,A\n"},
+			constraint: `allowed_values_file:
+          path: codes.csv
+          column: id`,
+			wantError: `:6:17: failed to load values file codes.csv in rule "values": column "id" not found in header (columns: code, name)`,
+		},
+		{
+			name:       "empty file",
+			files:      map[string]string{"empty.txt": "# nothing yet\n"},
+			constraint: `allowed_values_file: empty.txt`,
+			wantError:  `:5:30: failed to load values file empty.txt in rule "values": no values found`,
+		},
+		{
+			name:  "column for newline list",
+			files: map[string]string{"teams.txt": "platform\n"},
+			constraint: `allowed_values_file:
+          path: teams.txt
+          column: team`,
+			wantError: `:7:19: column for values file teams.txt in rule "values" requires csv or json format`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write values file: %v", err)
+				}
+			}
+
+			configPath := filepath.Join(dir, "tag-rules.yaml")
+			content := `rules:
+  - name: values
+    tag_constraints:
+      - tag: Value
+        ` + tt.constraint + "\n"
+			if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+				t.Fatalf("Failed to write config: %v", err)
+			}
+
+			_, err := LoadConfig(configPath)
+			var validationErrors ValidationErrors
+			if !errors.As(err, &validationErrors) {
+				t.Fatalf("Expected ValidationErrors, got %v", err)
+			}
+			if !strings.Contains(err.Error(), configPath+tt.wantError) {
+				t.Errorf("Expected error containing %q, got:\n%v", tt.wantError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package config
+
+import "strings"
+
+// ValueType is the kind of value a tag must hold
+type ValueType string
+
+const (
+	TypeString  ValueType = "string"
+	TypeEmail   ValueType = "email"
+	TypeURL     ValueType = "url"
+	TypeInteger ValueType = "integer"
+	TypeBoolean ValueType = "boolean"
+	TypeDate    ValueType = "date"
+	TypeExpiry  ValueType = "expiry" // A date that must not have passed
+	TypeSemver  ValueType = "semver"
+)
+
+// valueTypeNames lists the supported types for error messages
+const valueTypeNames = "string, email, url, integer, boolean, date, expiry or semver"
+
+// IsValid reports whether t is a known value type; the empty type is valid
+// and means any string
+func (t ValueType) IsValid() bool {
+	switch t {
+	case "", TypeString, TypeEmail, TypeURL, TypeInteger, TypeBoolean, TypeDate, TypeExpiry, TypeSemver:
+		return true
+	}
+	return false
+}
+
+// DefaultDateFormat is the date format used when a constraint sets no formats
+const DefaultDateFormat = "YYYY-MM-DD"
+
+// DateLayout converts a date format written with the YYYY, MM and DD
+// placeholders, or the name rfc3339, into a time layout. It reports false if
+// the format lacks any of the placeholders.
+func DateLayout(format string) (string, bool) {
+	if format == "rfc3339" {
+		return "2006-01-02T15:04:05Z07:00", true
+	}
+	if !strings.Contains(format, "YYYY") || !strings.Contains(format, "MM") || !strings.Contains(format, "DD") {
+		return "", false
+	}
+	return strings.NewReplacer("YYYY", "2006", "MM", "01", "DD", "02").Replace(format), true
+}
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ParseBinaryPlan converts a binary plan file to JSON by running
+// `<terraformBin> show -json` and parses the output. The command runs in
+// workingDir, which must be the initialized root module the plan was created
+// from; it defaults to the directory containing the plan file.
+func ParseBinaryPlan(filename, terraformBin, workingDir string) (*ParseResult, error) {
+	var result *ParseResult
+	err := showPlanJSON(filename, terraformBin, workingDir, func(r io.Reader) error {
+		var err error
+		result, err = parsePlan(r, filename)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ParseBinaryPlanChanges is the ParseTerraformPlanChanges counterpart for
+// binary plan files; see ParseBinaryPlan
+func ParseBinaryPlanChanges(filename string, section ChangeSection, terraformBin, workingDir string) ([]ResourceChange, error) {
+	var changes []ResourceChange
+	err := showPlanJSON(filename, terraformBin, workingDir, func(r io.Reader) error {
+		var err error
+		changes, err = parsePlanChanges(r, filename, section)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// showPlanJSON runs `<terraformBin> show -json` on the plan and streams its
+// output to parse
+func showPlanJSON(filename, terraformBin, workingDir string, parse func(io.Reader) error) error {
+	planPath, err := filepath.Abs(filename)
+	if err != nil {
+		return fmt.Errorf("failed to resolve plan file path: %w", err)
+	}
+	if workingDir == "" {
+		workingDir = filepath.Dir(planPath)
+	}
+
+	binPath, err := exec.LookPath(terraformBin)
+	if err != nil {
+		return fmt.Errorf("%s not found; install it or set --terraform-bin to a terraform or tofu binary: %w", terraformBin, err)
+	}
+
+	cmd := exec.Command(binPath, "show", "-json", planPath)
+	cmd.Dir = workingDir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("failed to run %s: %w", terraformBin, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", terraformBin, err)
+	}
+
+	parseErr := parse(bufio.NewReader(stdout))
+
+	// Drain any remaining output so the process can exit
+	io.Copy(io.Discard, stdout)
+	if err := cmd.Wait(); err != nil {
+		return showError(terraformBin, stderr.String(), err)
+	}
+	return parseErr
+}
+
+// showError turns a failed `show -json` invocation into an actionable error
+func showError(terraformBin, stderr string, err error) error {
+	msg := strings.TrimSpace(stderr)
+	if msg == "" {
+		msg = err.Error()
+	}
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return fmt.Errorf("failed to run %s show -json: %s", terraformBin, msg)
+	}
+
+	lower := strings.ToLower(msg)
+	if strings.Contains(lower, "was created by") || strings.Contains(lower, "different terraform versions") {
+		return fmt.Errorf("plan was created by a different version than %s; use the same version that ran the plan: %s", terraformBin, msg)
+	}
+
+	return fmt.Errorf("%s show -json failed (exit status %d): %s", terraformBin, exitErr.ExitCode(), msg)
+}
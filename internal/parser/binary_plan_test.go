@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"archive/zip"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// writeBinaryPlan writes a minimal zip archive standing in for a binary plan file
+func writeBinaryPlan(t *testing.T, path string) {
+	t.Helper()
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create plan file: %v", err)
+	}
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	if _, err := zw.Create("tfplan"); err != nil {
+		t.Fatalf("Failed to write plan archive: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("Failed to write plan archive: %v", err)
+	}
+}
+
+// writeFakeTerraform writes a shell script that behaves like `terraform show -json`
+func writeFakeTerraform(t *testing.T, dir, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake terraform binary requires a POSIX shell")
+	}
+	path := filepath.Join(dir, "terraform")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatalf("Failed to write fake terraform: %v", err)
+	}
+	return path
+}
+
+func TestParseTerraformPlan_BinaryPlan(t *testing.T) {
+	planPath := filepath.Join(t.TempDir(), "tfplan")
+	writeBinaryPlan(t, planPath)
+
+	_, err := ParseTerraformPlan(planPath)
+	if !errors.Is(err, ErrBinaryPlan) {
+		t.Errorf("Expected ErrBinaryPlan, got %v", err)
+	}
+}
+
+func TestParseBinaryPlan(t *testing.T) {
+	tests := []struct {
+		name       string
+		script     string
+		workingDir bool
+		wantErr    string
+		wantCount  int
+	}{
+		{
+			name: "converts plan with terraform show -json",
+			script: `[ "$1 $2" = "show -json" ] || exit 2
+echo '{"planned_values": {"root_module": {"resources": [{"address": "aws_instance.web", "values": {"tags": {"Name": "web"}}}]}}}'
+`,
+			wantCount: 1,
+		},
+		{
+			name: "runs in the configured working directory",
+			script: `[ -f marker ] || { echo "Error: not initialized" >&2; exit 1; }
+echo '{"planned_values": {"root_module": {}}}'
+`,
+			workingDir: true,
+			wantCount:  0,
+		},
+		{
+			name: "version mismatch",
+			script: `echo "Error: plan file was created by Terraform 1.4.0, but this is 1.5.7; plan files cannot be transferred between different Terraform versions." >&2
+exit 1
+`,
+			wantErr: "different version",
+		},
+		{
+			name: "other failures include stderr",
+			script: `echo "Error: Inconsistent dependency lock file" >&2
+exit 1
+`,
+			wantErr: "Inconsistent dependency lock file",
+		},
+		{
+			name: "invalid JSON output",
+			script: `echo 'not json'
+`,
+			wantErr: "failed to parse plan JSON",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binDir := t.TempDir()
+			planDir := t.TempDir()
+			planPath := filepath.Join(planDir, "tfplan")
+			writeBinaryPlan(t, planPath)
+			bin := writeFakeTerraform(t, binDir, tt.script)
+
+			workingDir := ""
+			if tt.workingDir {
+				workingDir = t.TempDir()
+				if err := os.WriteFile(filepath.Join(workingDir, "marker"), nil, 0644); err != nil {
+					t.Fatalf("Failed to write marker: %v", err)
+				}
+			}
+
+			result, err := ParseBinaryPlan(planPath, bin, workingDir)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBinaryPlan() error = %v", err)
+			}
+			if len(result.Resources) != tt.wantCount {
+				t.Errorf("Expected %d resources, got %d", tt.wantCount, len(result.Resources))
+			}
+			for _, res := range result.Resources {
+				if res.File != planPath {
+					t.Errorf("Expected resource file %s, got %s", planPath, res.File)
+				}
+			}
+		})
+	}
+}
+
+func TestParseBinaryPlan_BinaryNotFound(t *testing.T) {
+	planPath := filepath.Join(t.TempDir(), "tfplan")
+	writeBinaryPlan(t, planPath)
+
+	_, err := ParseBinaryPlan(planPath, "tftaglint-no-such-terraform", "")
+	if err == nil || !strings.Contains(err.Error(), "--terraform-bin") {
+		t.Errorf("Expected error mentioning --terraform-bin, got %v", err)
+	}
+}
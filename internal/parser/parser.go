@@ -13,11 +13,25 @@ import (
 )
 
 type Resource struct {
-	Type     string
-	Name     string
-	Tags     map[string]string
-	Location hcl.Range
-	File     string
+	Type      string
+	Name      string
+	Mode      string // ModeManaged for resource blocks, ModeData for data sources
+	Tags      map[string]string
+	Location  hcl.Range
+	File      string
+	Address   string // Full resource address (plan and state inputs only)
+	Workspace string // Plan the resource was read from when validating several plans
+}
+
+// Resource modes, as used in plan and state JSON
+const (
+	ModeManaged = "managed"
+	ModeData    = "data"
+)
+
+// IsDataSource reports whether the resource is a data source
+func (r Resource) IsDataSource() bool {
+	return r.Mode == ModeData
 }
 
 // PlanResource is used for resources parsed from terraform plan
@@ -83,17 +97,22 @@ func parseFile(filename string) ([]Resource, error) {
 	var resources []Resource
 
 	for _, block := range body.Blocks {
-		if block.Type == "resource" && len(block.Labels) >= 2 {
+		if (block.Type == "resource" || block.Type == "data") && len(block.Labels) >= 2 {
+			mode := ModeManaged
+			if block.Type == "data" {
+				mode = ModeData
+			}
 			resource := Resource{
 				Type:     block.Labels[0],
 				Name:     block.Labels[1],
+				Mode:     mode,
 				Tags:     make(map[string]string),
 				Location: block.DefRange(),
 				File:     filename,
 			}
 
 			// Extract tags
-			tags := extractTags(block.Body)
+			tags := extractTags(block.Labels[0], block.Body)
 			resource.Tags = tags
 			
 
@@ -104,13 +123,21 @@ func parseFile(filename string) ([]Resource, error) {
 	return resources, nil
 }
 
-func extractTags(body *hclsyntax.Body) map[string]string {
+// tagAttribute returns the attribute holding a resource's tags. Google
+// resources keep them in labels, as their tags attribute is a list of
+// network tags
+func tagAttribute(resourceType string) string {
+	if strings.HasPrefix(resourceType, "google_") {
+		return "labels"
+	}
+	return "tags"
+}
+
+func extractTags(resourceType string, body *hclsyntax.Body) map[string]string {
 	tags := make(map[string]string)
 
-	for name, attr := range body.Attributes {
-		if name == "tags" {
-			extractTagsFromExpression(attr.Expr, tags)
-		}
+	if attr, ok := body.Attributes[tagAttribute(resourceType)]; ok {
+		extractTagsFromExpression(attr.Expr, tags)
 	}
 
 	// Also check for tags block
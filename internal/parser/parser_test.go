@@ -321,6 +321,23 @@ resource "aws_instance" "example" {
 				}
 			},
 		},
+		{
+			name: "google labels",
+			content: `
+resource "google_compute_instance" "web" {
+  tags = ["web", "ssh"]
+  labels = {
+    owner = "team-a"
+  }
+}`,
+			wantErr: false,
+			check: func(t *testing.T, resources []Resource) {
+				res := resources[0]
+				if len(res.Tags) != 1 || res.Tags["owner"] != "team-a" {
+					t.Errorf("Expected owner label as the only tag, got %v", res.Tags)
+				}
+			},
+		},
 		{
 			name: "resource location tracking",
 			content: `resource "aws_instance" "test" {
@@ -336,6 +353,34 @@ resource "aws_instance" "example" {
 				}
 			},
 		},
+		{
+			name: "data sources",
+			content: `
+data "aws_vpc" "main" {
+  tags = {
+    Name = "main"
+  }
+}
+
+resource "aws_subnet" "private" {
+  tags = {}
+}`,
+			wantErr: false,
+			check: func(t *testing.T, resources []Resource) {
+				if len(resources) != 2 {
+					t.Fatalf("Expected 2 resources, got %d", len(resources))
+				}
+				if resources[0].Mode != ModeData || !resources[0].IsDataSource() {
+					t.Errorf("Expected data source, got mode %q", resources[0].Mode)
+				}
+				if resources[0].Tags["Name"] != "main" {
+					t.Errorf("Expected Name tag 'main', got %v", resources[0].Tags)
+				}
+				if resources[1].Mode != ModeManaged || resources[1].IsDataSource() {
+					t.Errorf("Expected managed resource, got mode %q", resources[1].Mode)
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
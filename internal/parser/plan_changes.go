@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ChangeSection selects which list of resource changes is read from a plan
+type ChangeSection string
+
+const (
+	// ResourceChanges are the changes Terraform plans to make
+	ResourceChanges ChangeSection = "resource_changes"
+	// ResourceDrift are the changes made outside Terraform since the last apply
+	ResourceDrift ChangeSection = "resource_drift"
+)
+
+// ResourceChange is a resource from the resource_changes or resource_drift
+// section of a plan, with its tags before and after the change
+type ResourceChange struct {
+	Address         string
+	PreviousAddress string // Set when the resource was moved, e.g. by a moved block
+	Actions         []string
+	Before          *Resource // nil when the resource did not exist before the change
+	After           *Resource // nil when the resource does not exist after the change
+}
+
+// streamedChange is the subset of a resource change materialized while streaming
+type streamedChange struct {
+	Address         string `json:"address"`
+	PreviousAddress string `json:"previous_address"`
+	Mode            string `json:"mode"`
+	Type            string `json:"type"`
+	Name            string `json:"name"`
+	Change          struct {
+		Actions []string        `json:"actions"`
+		Before  *streamedValues `json:"before"`
+		After   *streamedValues `json:"after"`
+	} `json:"change"`
+}
+
+// ParseTerraformPlanChanges parses a terraform plan JSON file and returns the
+// resource changes recorded in the given section
+func ParseTerraformPlanChanges(filename string, section ChangeSection) ([]ResourceChange, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan file: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	if magic, _ := reader.Peek(len(zipMagic)); bytes.Equal(magic, zipMagic) {
+		return nil, fmt.Errorf("%s: %w", filename, ErrBinaryPlan)
+	}
+
+	return parsePlanChanges(reader, filename, section)
+}
+
+// parsePlanChanges streams the given section of plan JSON from r
+func parsePlanChanges(r io.Reader, filename string, section ChangeSection) ([]ResourceChange, error) {
+	changes := []ResourceChange{}
+
+	dec := json.NewDecoder(r)
+	err := walkObject(dec, func(key string) error {
+		if key != string(section) {
+			return skipValue(dec)
+		}
+		return walkArray(dec, func() error {
+			var change streamedChange
+			if err := dec.Decode(&change); err != nil {
+				return err
+			}
+			changes = append(changes, change.resourceChange(filename))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse plan JSON: %w", err)
+	}
+
+	return changes, nil
+}
+
+func (c streamedChange) resourceChange(filename string) ResourceChange {
+	convert := func(values *streamedValues) *Resource {
+		if values == nil {
+			return nil
+		}
+		return convertPlannedResource(PlannedResource{
+			Address: c.Address,
+			Mode:    c.Mode,
+			Type:    c.Type,
+			Name:    c.Name,
+			Values:  values.values(),
+		}, filename)
+	}
+
+	return ResourceChange{
+		Address:         c.Address,
+		PreviousAddress: c.PreviousAddress,
+		Actions:         c.Change.Actions,
+		Before:          convert(c.Change.Before),
+		After:           convert(c.Change.After),
+	}
+}
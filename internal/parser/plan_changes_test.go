@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const changesPlan = `{
+  "format_version": "1.2",
+  "resource_drift": [
+    {
+      "address": "aws_instance.web",
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "change": {
+        "actions": ["update"],
+        "before": {"ami": "ami-123", "tags": {"Name": "web", "Owner": "team-a"}},
+        "after": {"ami": "ami-123", "tags": {"Name": "web", "Owner": "console-user"}}
+      }
+    },
+    {
+      "address": "aws_s3_bucket.logs",
+      "mode": "managed",
+      "type": "aws_s3_bucket",
+      "name": "logs",
+      "change": {"actions": ["delete"], "before": {"tags": {"Name": "logs"}}, "after": null}
+    }
+  ],
+  "planned_values": {"root_module": {}},
+  "resource_changes": [
+    {
+      "address": "module.app.aws_instance.api",
+      "previous_address": "aws_instance.api",
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "api",
+      "change": {
+        "actions": ["create"],
+        "before": null,
+        "after": {"tags": {"Name": "api"}, "tags_all": {"Name": "api", "ManagedBy": "Terraform"}}
+      }
+    }
+  ]
+}`
+
+func TestParseTerraformPlanChanges(t *testing.T) {
+	tmpFile := filepath.Join(t.TempDir(), "plan.json")
+	if err := os.WriteFile(tmpFile, []byte(changesPlan), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+
+	t.Run("resource_drift", func(t *testing.T) {
+		changes, err := ParseTerraformPlanChanges(tmpFile, ResourceDrift)
+		if err != nil {
+			t.Fatalf("ParseTerraformPlanChanges() error = %v", err)
+		}
+		if len(changes) != 2 {
+			t.Fatalf("Expected 2 changes, got %d", len(changes))
+		}
+
+		web := changes[0]
+		if web.Address != "aws_instance.web" || !reflect.DeepEqual(web.Actions, []string{"update"}) {
+			t.Errorf("Unexpected change %s %v", web.Address, web.Actions)
+		}
+		if web.Before == nil || web.Before.Tags["Owner"] != "team-a" {
+			t.Errorf("Expected before Owner tag team-a, got %+v", web.Before)
+		}
+		if web.After == nil || web.After.Tags["Owner"] != "console-user" {
+			t.Errorf("Expected after Owner tag console-user, got %+v", web.After)
+		}
+		if web.After.File != tmpFile || web.After.Address != "aws_instance.web" {
+			t.Errorf("Unexpected resource file/address: %s %s", web.After.File, web.After.Address)
+		}
+
+		if changes[1].After != nil {
+			t.Errorf("Expected nil after for deleted resource, got %+v", changes[1].After)
+		}
+	})
+
+	t.Run("resource_changes", func(t *testing.T) {
+		changes, err := ParseTerraformPlanChanges(tmpFile, ResourceChanges)
+		if err != nil {
+			t.Fatalf("ParseTerraformPlanChanges() error = %v", err)
+		}
+		if len(changes) != 1 {
+			t.Fatalf("Expected 1 change, got %d", len(changes))
+		}
+		if changes[0].Before != nil {
+			t.Errorf("Expected nil before for created resource, got %+v", changes[0].Before)
+		}
+		if changes[0].PreviousAddress != "aws_instance.api" {
+			t.Errorf("Expected previous address aws_instance.api, got %q", changes[0].PreviousAddress)
+		}
+		wantTags := map[string]string{"Name": "api", "ManagedBy": "Terraform"}
+		if !reflect.DeepEqual(changes[0].After.Tags, wantTags) {
+			t.Errorf("Expected tags %v, got %v", wantTags, changes[0].After.Tags)
+		}
+		if changes[0].After.Type != "aws_instance" || changes[0].After.Name != "api" {
+			t.Errorf("Expected aws_instance.api, got %s.%s", changes[0].After.Type, changes[0].After.Name)
+		}
+	})
+}
+
+func TestParseTerraformPlanChanges_Errors(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	invalid := filepath.Join(tmpDir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"resource_drift": {}}`), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+	if _, err := ParseTerraformPlanChanges(invalid, ResourceDrift); err == nil {
+		t.Error("Expected error for non-array section")
+	}
+
+	binary := filepath.Join(tmpDir, "tfplan")
+	writeBinaryPlan(t, binary)
+	if _, err := ParseTerraformPlanChanges(binary, ResourceDrift); !errors.Is(err, ErrBinaryPlan) {
+		t.Errorf("Expected ErrBinaryPlan, got %v", err)
+	}
+
+	if _, err := ParseTerraformPlanChanges(filepath.Join(tmpDir, "missing.json"), ResourceDrift); err == nil {
+		t.Error("Expected error for non-existent file")
+	}
+}
@@ -1,8 +1,12 @@
 package parser
 
 import (
+	"bufio"
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -31,35 +35,209 @@ type ChildModule struct {
 
 type PlannedResource struct {
 	Address      string                 `json:"address"`
+	Mode         string                 `json:"mode,omitempty"`
 	Type         string                 `json:"type"`
 	Name         string                 `json:"name"`
 	Values       map[string]interface{} `json:"values"`
 	ModulePath   []string              `json:"module_path,omitempty"`
 }
 
-// ParseTerraformPlan parses a terraform plan JSON file and extracts resources with tags
+// streamedValues holds the only resource values materialized while streaming a plan
+type streamedValues struct {
+	Tags            interface{} `json:"tags"`
+	TagsAll         interface{} `json:"tags_all"`
+	Labels          interface{} `json:"labels"`
+	TerraformLabels interface{} `json:"terraform_labels"`
+}
+
+func (v streamedValues) values() map[string]interface{} {
+	values := make(map[string]interface{})
+	if v.Tags != nil {
+		values["tags"] = v.Tags
+	}
+	if v.TagsAll != nil {
+		values["tags_all"] = v.TagsAll
+	}
+	if v.Labels != nil {
+		values["labels"] = v.Labels
+	}
+	if v.TerraformLabels != nil {
+		values["terraform_labels"] = v.TerraformLabels
+	}
+	return values
+}
+
+// streamedResource is the subset of a planned resource that is materialized
+// while streaming a plan; all other values are skipped by the decoder
+type streamedResource struct {
+	Address string         `json:"address"`
+	Mode    string         `json:"mode"`
+	Type    string         `json:"type"`
+	Name    string         `json:"name"`
+	Values  streamedValues `json:"values"`
+}
+
+func (r streamedResource) planned() PlannedResource {
+	return PlannedResource{
+		Address: r.Address,
+		Mode:    r.Mode,
+		Type:    r.Type,
+		Name:    r.Name,
+		Values:  r.Values.values(),
+	}
+}
+
+// ErrBinaryPlan is returned by ParseTerraformPlan when the file is a binary plan
+// created by `terraform plan -out` rather than its JSON representation
+var ErrBinaryPlan = errors.New("binary plan file")
+
+// zipMagic is the signature at the start of binary plan files, which are zip archives
+var zipMagic = []byte("PK\x03\x04")
+
+// ParseTerraformPlan parses a terraform plan JSON file and extracts resources with tags.
+// The plan is streamed so that memory use is bounded by the largest single resource
+// rather than the size of the whole document.
 func ParseTerraformPlan(filename string) (*ParseResult, error) {
-	data, err := os.ReadFile(filename)
+	file, err := os.Open(filename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read plan file: %w", err)
 	}
+	defer file.Close()
 
-	var plan TerraformPlan
-	if err := json.Unmarshal(data, &plan); err != nil {
-		return nil, fmt.Errorf("failed to parse plan JSON: %w", err)
+	reader := bufio.NewReader(file)
+	if magic, _ := reader.Peek(len(zipMagic)); bytes.Equal(magic, zipMagic) {
+		return nil, fmt.Errorf("%s: %w", filename, ErrBinaryPlan)
 	}
 
+	return parsePlan(reader, filename)
+}
+
+// parsePlan streams plan JSON from r; filename is used for resource locations
+func parsePlan(r io.Reader, filename string) (*ParseResult, error) {
 	result := &ParseResult{
 		Resources: []Resource{},
 		Errors:    []error{},
 	}
 
-	// Process root module resources
-	processModuleResources(&plan.PlannedValues.RootModule, filename, result)
+	dec := json.NewDecoder(r)
+	err := walkObject(dec, func(key string) error {
+		if key != "planned_values" {
+			return skipValue(dec)
+		}
+		return walkObject(dec, func(key string) error {
+			if key != "root_module" {
+				return skipValue(dec)
+			}
+			return streamModuleResources(dec, filename, result)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse plan JSON: %w", err)
+	}
 
 	return result, nil
 }
 
+// streamModuleResources decodes a root or child module object, including its
+// nested child modules
+func streamModuleResources(dec *json.Decoder, filename string, result *ParseResult) error {
+	return walkObject(dec, func(key string) error {
+		switch key {
+		case "resources":
+			return walkArray(dec, func() error {
+				var resource streamedResource
+				if err := dec.Decode(&resource); err != nil {
+					return err
+				}
+				if res := convertPlannedResource(resource.planned(), filename); res != nil {
+					result.Resources = append(result.Resources, *res)
+				}
+				return nil
+			})
+		case "child_modules":
+			return walkArray(dec, func() error {
+				return streamModuleResources(dec, filename, result)
+			})
+		default:
+			return skipValue(dec)
+		}
+	})
+}
+
+// walkObject reads a JSON object and calls fn for each key; fn must consume the
+// key's value. A null value is treated as an empty object.
+func walkObject(dec *json.Decoder, fn func(key string) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected object, got %v", tok)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if err := fn(tok.(string)); err != nil {
+			return err
+		}
+	}
+
+	// Consume the closing brace
+	_, err = dec.Token()
+	return err
+}
+
+// walkArray reads a JSON array and calls fn for each element; fn must consume
+// the element. A null value is treated as an empty array.
+func walkArray(dec *json.Decoder, fn func() error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected array, got %v", tok)
+	}
+
+	for dec.More() {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	// Consume the closing bracket
+	_, err = dec.Token()
+	return err
+}
+
+// skipValue consumes the next JSON value token by token without materializing it
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
 func processModuleResources(module *RootModule, filename string, result *ParseResult) {
 	// Process resources in this module
 	for _, resource := range module.Resources {
@@ -91,17 +269,54 @@ func processChildModule(module *ChildModule, filename string, result *ParseResul
 }
 
 func convertPlannedResource(planned PlannedResource, filename string) *Resource {
-	// Extract resource type and name from address
+	resourceType, resourceName := planned.Type, planned.Name
+	if resourceType == "" || resourceName == "" {
+		var ok bool
+		if resourceType, resourceName, ok = splitResourceAddress(planned.Address); !ok {
+			return nil
+		}
+	}
+
+	resource := &Resource{
+		Type:     resourceType,
+		Name:     resourceName,
+		Mode:     planned.Mode,
+		Tags:     extractTagsFromValues(resourceType, planned.Values),
+		Location: fileLocation(filename),
+		File:     filename,
+		Address:  planned.Address,
+	}
+
+	return resource
+}
+
+// fileLocation returns the location used for resources read from JSON inputs,
+// which carry no line numbers
+func fileLocation(filename string) hcl.Range {
+	return hcl.Range{
+		Filename: filename,
+		Start: hcl.Pos{
+			Line:   1,
+			Column: 1,
+			Byte:   0,
+		},
+		End: hcl.Pos{
+			Line:   1,
+			Column: 1,
+			Byte:   0,
+		},
+	}
+}
+
+// splitResourceAddress extracts the resource type and name from an address,
+// for inputs that do not carry them separately
+func splitResourceAddress(address string) (resourceType, resourceName string, ok bool) {
 	// Format: module.name.resource_type.resource_name or resource_type.resource_name
-	parts := strings.Split(planned.Address, ".")
+	parts := strings.Split(address, ".")
 	if len(parts) < 2 {
-		return nil
+		return "", "", false
 	}
 
-	// Find resource type and name
-	resourceType := ""
-	resourceName := ""
-	
 	// Look for the resource type (starts with provider prefix like aws_, google_, etc.)
 	for i := 0; i < len(parts)-1; i++ {
 		if isResourceType(parts[i]) {
@@ -115,35 +330,11 @@ func convertPlannedResource(planned PlannedResource, filename string) *Resource
 
 	if resourceType == "" || resourceName == "" {
 		// Fallback: assume last two parts are type and name
-		if len(parts) >= 2 {
-			resourceType = parts[len(parts)-2]
-			resourceName = parts[len(parts)-1]
-		} else {
-			return nil
-		}
-	}
-
-	resource := &Resource{
-		Type: resourceType,
-		Name: resourceName,
-		Tags: extractTagsFromValues(planned.Values),
-		Location: hcl.Range{
-			Filename: filename,
-			Start: hcl.Pos{
-				Line:   1, // Plan doesn't have line numbers
-				Column: 1,
-				Byte:   0,
-			},
-			End: hcl.Pos{
-				Line:   1,
-				Column: 1,
-				Byte:   0,
-			},
-		},
-		File: filename,
+		resourceType = parts[len(parts)-2]
+		resourceName = parts[len(parts)-1]
 	}
 
-	return resource
+	return resourceType, resourceName, true
 }
 
 func isResourceType(s string) bool {
@@ -157,24 +348,23 @@ func isResourceType(s string) bool {
 	return false
 }
 
-func extractTagsFromValues(values map[string]interface{}) map[string]string {
-	tags := make(map[string]string)
-
-	// Look for "tags" field
-	if tagsValue, ok := values["tags"]; ok {
-		switch t := tagsValue.(type) {
-		case map[string]interface{}:
-			for k, v := range t {
-				if str, ok := v.(string); ok {
-					tags[k] = str
-				}
-			}
-		}
+// computedTagAttribute returns the attribute in which providers report a
+// resource's tags merged with their defaults. It is computed, so it only
+// appears in plans and state.
+func computedTagAttribute(resourceType string) string {
+	if strings.HasPrefix(resourceType, "google_") {
+		return "terraform_labels"
 	}
+	return "tags_all"
+}
 
-	// Also check for "tags_all" (AWS provider sometimes uses this)
-	if tagsAllValue, ok := values["tags_all"]; ok {
-		switch t := tagsAllValue.(type) {
+func extractTagsFromValues(resourceType string, values map[string]interface{}) map[string]string {
+	tags := make(map[string]string)
+
+	// Look for "tags" and "tags_all" (AWS provider sometimes uses this), or
+	// "labels" and "terraform_labels" on Google resources
+	for _, name := range []string{tagAttribute(resourceType), computedTagAttribute(resourceType)} {
+		switch t := values[name].(type) {
 		case map[string]interface{}:
 			for k, v := range t {
 				if str, ok := v.(string); ok {
@@ -186,4 +376,3 @@ func extractTagsFromValues(values map[string]interface{}) map[string]string {
 
 	return tags
 }
-
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -341,6 +342,125 @@ func TestParseTerraformPlan_FileNotFound(t *testing.T) {
 	}
 }
 
+func TestParseTerraformPlan_Streaming(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantErr     bool
+		wantAddress []string
+	}{
+		{
+			name: "planned_values after other sections",
+			content: `{
+  "format_version": "1.1",
+  "prior_state": {"values": {"root_module": {"resources": [{"address": "aws_instance.old", "values": {"tags": {"A": "b"}}}]}}},
+  "resource_changes": [{"address": "aws_instance.web", "change": {"actions": ["create"], "after": {"tags": null}}}],
+  "configuration": {"root_module": {"resources": [{"address": "aws_instance.web", "expressions": {"tags": {"references": ["local.tags"]}}}]}},
+  "planned_values": {
+    "outputs": {"id": {"sensitive": false}},
+    "root_module": {
+      "resources": [
+        {
+          "address": "aws_instance.web",
+          "type": "aws_instance",
+          "name": "web",
+          "values": {
+            "user_data": "IyEvYmluL2Jhc2gK",
+            "ebs_block_device": [{"volume_size": 8, "tags": {"Nested": "ignored"}}],
+            "tags": {"Name": "web"}
+          }
+        }
+      ],
+      "child_modules": [
+        {
+          "address": "module.app",
+          "child_modules": [
+            {
+              "address": "module.app.module.db",
+              "resources": [{"address": "module.app.module.db.aws_db_instance.main", "values": {"tags_all": {"Name": "db"}}}]
+            }
+          ]
+        }
+      ]
+    }
+  }
+}`,
+			wantAddress: []string{"aws_instance.web", "module.app.module.db.aws_db_instance.main"},
+		},
+		{
+			name:        "null modules and resources",
+			content:     `{"planned_values": {"root_module": {"resources": null, "child_modules": null}}}`,
+			wantAddress: []string{},
+		},
+		{
+			name:        "no planned_values",
+			content:     `{"format_version": "1.1", "errored": true}`,
+			wantAddress: []string{},
+		},
+		{
+			name:    "truncated document",
+			content: `{"planned_values": {"root_module": {"resources": [{"address": "aws_instance.web"`,
+			wantErr: true,
+		},
+		{
+			name:    "top-level array",
+			content: `[{"planned_values": {}}]`,
+			wantErr: true,
+		},
+		{
+			name:    "resources not an array",
+			content: `{"planned_values": {"root_module": {"resources": {"address": "aws_instance.web"}}}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := filepath.Join(t.TempDir(), "plan.json")
+			if err := os.WriteFile(tmpFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write temp file: %v", err)
+			}
+
+			result, err := ParseTerraformPlan(tmpFile)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTerraformPlan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var got []string
+			for _, res := range result.Resources {
+				got = append(got, res.Address)
+			}
+			if len(got) != len(tt.wantAddress) {
+				t.Fatalf("Expected resources %v, got %v", tt.wantAddress, got)
+			}
+			for i := range got {
+				if got[i] != tt.wantAddress[i] {
+					t.Errorf("Resource %d: expected %s, got %s", i, tt.wantAddress[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSkipValue(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`{"a": [1, {"b": [true, null]}], "c": "d"} "next"`))
+	if err := skipValue(dec); err != nil {
+		t.Fatalf("skipValue() error = %v", err)
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if tok != "next" {
+		t.Errorf("Expected decoder to be positioned at next value, got %v", tok)
+	}
+}
+
 func TestConvertPlannedResource(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -411,6 +531,18 @@ func TestConvertPlannedResource(t *testing.T) {
 			},
 			wantNil: true,
 		},
+		{
+			name: "type and name from fields with dotted for_each key",
+			resource: PlannedResource{
+				Address: `kubernetes_namespace.ns["a.b"]`,
+				Type:    "kubernetes_namespace",
+				Name:    "ns",
+				Values:  map[string]interface{}{},
+			},
+			wantType: "kubernetes_namespace",
+			wantName: "ns",
+			wantNil:  false,
+		},
 		{
 			name: "data source address",
 			resource: PlannedResource{
@@ -478,9 +610,10 @@ func TestIsResourceType(t *testing.T) {
 
 func TestExtractTagsFromValues(t *testing.T) {
 	tests := []struct {
-		name   string
-		values map[string]interface{}
-		want   map[string]string
+		name         string
+		resourceType string
+		values       map[string]interface{}
+		want         map[string]string
 	}{
 		{
 			name: "tags field only",
@@ -551,11 +684,39 @@ func TestExtractTagsFromValues(t *testing.T) {
 			},
 			want: map[string]string{},
 		},
+		{
+			name:         "google labels and terraform_labels",
+			resourceType: "google_compute_instance",
+			values: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"owner": "team-a",
+				},
+				"terraform_labels": map[string]interface{}{
+					"owner":       "team-a",
+					"environment": "dev",
+				},
+				"tags": []interface{}{"web", "ssh"},
+			},
+			want: map[string]string{
+				"owner":       "team-a",
+				"environment": "dev",
+			},
+		},
+		{
+			name:         "labels ignored outside google",
+			resourceType: "aws_instance",
+			values: map[string]interface{}{
+				"labels": map[string]interface{}{
+					"owner": "team-a",
+				},
+			},
+			want: map[string]string{},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := extractTagsFromValues(tt.values)
+			got := extractTagsFromValues(tt.resourceType, tt.values)
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("extractTagsFromValues() = %v, want %v", got, tt.want)
 			}
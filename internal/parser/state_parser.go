@@ -0,0 +1,168 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// TerraformState represents the structure of `terraform show -json` output for a state
+type TerraformState struct {
+	FormatVersion string      `json:"format_version"`
+	Values        StateValues `json:"values"`
+}
+
+type StateValues struct {
+	RootModule RootModule `json:"root_module"`
+}
+
+// RawState represents the structure of a raw terraform.tfstate file (format version 4)
+type RawState struct {
+	Version   int                `json:"version"`
+	Resources []RawStateResource `json:"resources"`
+}
+
+type RawStateResource struct {
+	Module    string             `json:"module,omitempty"`
+	Mode      string             `json:"mode"`
+	Type      string             `json:"type"`
+	Name      string             `json:"name"`
+	Instances []RawStateInstance `json:"instances"`
+}
+
+type RawStateInstance struct {
+	IndexKey   interface{}            `json:"index_key,omitempty"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// stateDocument holds the top-level keys used to tell the supported state formats apart
+type stateDocument struct {
+	Values        json.RawMessage `json:"values"`
+	Version       *int            `json:"version"`
+	PlannedValues json.RawMessage `json:"planned_values"`
+}
+
+// ParseTerraformState parses either `terraform show -json` state output or a raw
+// terraform.tfstate file and extracts resources with tags
+func ParseTerraformState(filename string) (*ParseResult, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var doc stateDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse state JSON: %w", err)
+	}
+
+	result := &ParseResult{
+		Resources: []Resource{},
+		Errors:    []error{},
+	}
+
+	switch {
+	case doc.PlannedValues != nil:
+		return nil, fmt.Errorf("%s is a plan, not a state (use --plan instead)", filename)
+	case doc.Values != nil:
+		var state TerraformState
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, fmt.Errorf("failed to parse state JSON: %w", err)
+		}
+		processModuleResources(&state.Values.RootModule, filename, result)
+	case doc.Version != nil:
+		if *doc.Version != 4 {
+			return nil, fmt.Errorf("unsupported state file version %d (only version 4 is supported)", *doc.Version)
+		}
+		var state RawState
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, fmt.Errorf("failed to parse state JSON: %w", err)
+		}
+		processRawStateResources(state.Resources, filename, result)
+	default:
+		// `terraform show -json` prints only the format version for an empty state
+	}
+
+	return result, nil
+}
+
+// ParseTerraformJSON parses a plan JSON, `terraform show -json` state or raw
+// terraform.tfstate file, detecting the format from its top-level keys.
+// Binary plan files are reported with ErrBinaryPlan.
+func ParseTerraformJSON(filename string) (*ParseResult, error) {
+	isPlan, err := isPlanJSON(filename)
+	if err != nil {
+		return nil, err
+	}
+	if isPlan {
+		return ParseTerraformPlan(filename)
+	}
+	return ParseTerraformState(filename)
+}
+
+// isPlanJSON reports whether the file has a top-level planned_values key
+func isPlanJSON(filename string) (bool, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return false, fmt.Errorf("failed to read file: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	if magic, _ := reader.Peek(len(zipMagic)); bytes.Equal(magic, zipMagic) {
+		return false, fmt.Errorf("%s: %w", filename, ErrBinaryPlan)
+	}
+
+	isPlan := false
+	dec := json.NewDecoder(reader)
+	err = walkObject(dec, func(key string) error {
+		if key == "planned_values" {
+			isPlan = true
+		}
+		return skipValue(dec)
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+
+	return isPlan, nil
+}
+
+func processRawStateResources(resources []RawStateResource, filename string, result *ParseResult) {
+	for _, resource := range resources {
+		for _, instance := range resource.Instances {
+			result.Resources = append(result.Resources, Resource{
+				Type:     resource.Type,
+				Name:     resource.Name,
+				Mode:     resource.Mode,
+				Tags:     extractTagsFromValues(resource.Type, instance.Attributes),
+				Location: fileLocation(filename),
+				File:     filename,
+				Address:  rawStateAddress(resource, instance),
+			})
+		}
+	}
+}
+
+// rawStateAddress builds the full resource instance address, e.g.
+// module.vpc.aws_subnet.private["a"] or data.aws_ami.ubuntu
+func rawStateAddress(resource RawStateResource, instance RawStateInstance) string {
+	address := resource.Type + "." + resource.Name
+	if resource.Mode == "data" {
+		address = "data." + address
+	}
+	if resource.Module != "" {
+		address = resource.Module + "." + address
+	}
+
+	switch key := instance.IndexKey.(type) {
+	case float64:
+		address += "[" + strconv.FormatFloat(key, 'f', -1, 64) + "]"
+	case string:
+		address += "[" + strconv.Quote(key) + "]"
+	}
+
+	return address
+}
@@ -0,0 +1,245 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseTerraformState(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+		check   func(t *testing.T, result *ParseResult)
+	}{
+		{
+			name: "terraform show -json state output",
+			content: `{
+  "format_version": "1.0",
+  "terraform_version": "1.5.0",
+  "values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "aws_instance.web",
+          "mode": "managed",
+          "type": "aws_instance",
+          "name": "web",
+          "values": {
+            "tags": {"Name": "web-server"},
+            "tags_all": {"Name": "web-server", "ManagedBy": "Terraform"}
+          }
+        }
+      ],
+      "child_modules": [
+        {
+          "address": "module.vpc",
+          "resources": [
+            {
+              "address": "module.vpc.aws_vpc.main",
+              "mode": "managed",
+              "type": "aws_vpc",
+              "name": "main",
+              "values": {"tags": {"Name": "main-vpc"}}
+            }
+          ]
+        }
+      ]
+    }
+  }
+}`,
+			check: func(t *testing.T, result *ParseResult) {
+				if len(result.Resources) != 2 {
+					t.Fatalf("Expected 2 resources, got %d", len(result.Resources))
+				}
+				res := result.Resources[0]
+				if res.Address != "aws_instance.web" {
+					t.Errorf("Expected address aws_instance.web, got %s", res.Address)
+				}
+				expectedTags := map[string]string{
+					"Name":      "web-server",
+					"ManagedBy": "Terraform",
+				}
+				if !reflect.DeepEqual(res.Tags, expectedTags) {
+					t.Errorf("Tags mismatch. Expected %v, got %v", expectedTags, res.Tags)
+				}
+				if result.Resources[1].Address != "module.vpc.aws_vpc.main" {
+					t.Errorf("Expected address module.vpc.aws_vpc.main, got %s", result.Resources[1].Address)
+				}
+			},
+		},
+		{
+			name: "raw tfstate with indexed instances",
+			content: `{
+  "version": 4,
+  "terraform_version": "1.5.0",
+  "serial": 3,
+  "lineage": "0b6f3a2c",
+  "outputs": {},
+  "resources": [
+    {
+      "mode": "managed",
+      "type": "aws_instance",
+      "name": "web",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {"index_key": 0, "attributes": {"tags": {"Name": "web-0"}}},
+        {"index_key": 1, "attributes": {"tags": {"Name": "web-1"}}}
+      ]
+    },
+    {
+      "module": "module.network",
+      "mode": "managed",
+      "type": "aws_subnet",
+      "name": "private",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {"index_key": "a", "attributes": {"tags_all": {"Tier": "private"}}}
+      ]
+    },
+    {
+      "mode": "data",
+      "type": "aws_ami",
+      "name": "ubuntu",
+      "provider": "provider[\"registry.terraform.io/hashicorp/aws\"]",
+      "instances": [
+        {"attributes": {"tags": null}}
+      ]
+    }
+  ]
+}`,
+			check: func(t *testing.T, result *ParseResult) {
+				wantAddresses := []string{
+					"aws_instance.web[0]",
+					"aws_instance.web[1]",
+					`module.network.aws_subnet.private["a"]`,
+					"data.aws_ami.ubuntu",
+				}
+				if len(result.Resources) != len(wantAddresses) {
+					t.Fatalf("Expected %d resources, got %d", len(wantAddresses), len(result.Resources))
+				}
+				for i, want := range wantAddresses {
+					if result.Resources[i].Address != want {
+						t.Errorf("Resource %d: expected address %s, got %s", i, want, result.Resources[i].Address)
+					}
+				}
+				if result.Resources[1].Tags["Name"] != "web-1" {
+					t.Errorf("Expected Name tag web-1, got %v", result.Resources[1].Tags)
+				}
+				subnet := result.Resources[2]
+				if subnet.Type != "aws_subnet" || subnet.Name != "private" {
+					t.Errorf("Expected aws_subnet.private, got %s.%s", subnet.Type, subnet.Name)
+				}
+				if subnet.Tags["Tier"] != "private" {
+					t.Errorf("Expected Tier tag from tags_all, got %v", subnet.Tags)
+				}
+				if len(result.Resources[3].Tags) != 0 {
+					t.Errorf("Expected no tags for data source, got %v", result.Resources[3].Tags)
+				}
+			},
+		},
+		{
+			name:    "empty state from terraform show -json",
+			content: `{"format_version": "1.0"}`,
+			check: func(t *testing.T, result *ParseResult) {
+				if len(result.Resources) != 0 {
+					t.Errorf("Expected no resources, got %d", len(result.Resources))
+				}
+			},
+		},
+		{
+			name:    "unsupported raw state version",
+			content: `{"version": 3, "modules": []}`,
+			wantErr: true,
+		},
+		{
+			name:    "plan passed as state",
+			content: `{"format_version": "1.1", "planned_values": {"root_module": {}}}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid json",
+			content: "invalid json string",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+			if err := os.WriteFile(tmpFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write temp file: %v", err)
+			}
+
+			result, err := ParseTerraformState(tmpFile)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTerraformState() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && tt.check != nil {
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+func TestParseTerraformState_FileNotFound(t *testing.T) {
+	_, err := ParseTerraformState("/non/existent/terraform.tfstate")
+	if err == nil {
+		t.Error("Expected error for non-existent file")
+	}
+}
+
+func TestParseTerraformJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantAddress string
+		wantErr     bool
+	}{
+		{
+			name:        "plan JSON",
+			content:     `{"prior_state": {"values": {}}, "planned_values": {"root_module": {"resources": [{"address": "aws_instance.planned", "values": {}}]}}}`,
+			wantAddress: "aws_instance.planned",
+		},
+		{
+			name:        "state JSON",
+			content:     `{"format_version": "1.0", "values": {"root_module": {"resources": [{"address": "aws_instance.state", "values": {}}]}}}`,
+			wantAddress: "aws_instance.state",
+		},
+		{
+			name:        "raw tfstate",
+			content:     `{"version": 4, "resources": [{"mode": "managed", "type": "aws_instance", "name": "raw", "instances": [{"attributes": {}}]}]}`,
+			wantAddress: "aws_instance.raw",
+		},
+		{
+			name:    "invalid json",
+			content: `{"planned_values": `,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := filepath.Join(t.TempDir(), "input.json")
+			if err := os.WriteFile(tmpFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Failed to write temp file: %v", err)
+			}
+
+			result, err := ParseTerraformJSON(tmpFile)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTerraformJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(result.Resources) != 1 || result.Resources[0].Address != tt.wantAddress {
+				t.Errorf("Expected resource %s, got %+v", tt.wantAddress, result.Resources)
+			}
+		})
+	}
+}
@@ -1,11 +1,15 @@
 package reporter
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
 	"strings"
 
+	"github.com/tom-023/tftaglint/internal/config"
+	"github.com/tom-023/tftaglint/internal/parser"
+	"github.com/tom-023/tftaglint/internal/tagdiff"
 	"github.com/tom-023/tftaglint/internal/validator"
 )
 
@@ -58,31 +62,113 @@ func (r *Reporter) Report(violations []validator.Violation) error {
 
 func (r *Reporter) reportViolation(v validator.Violation) {
 	location := v.Resource.Location.Start
-	fmt.Fprintf(r.writer, "  Line %d: %s.%s\n", location.Line, v.Resource.Type, v.Resource.Name)
+	fmt.Fprintf(r.writer, "  Line %d: %s\n", location.Line, resourceName(v.Resource))
 	fmt.Fprintf(r.writer, "    Rule: %s\n", v.Rule)
+	fmt.Fprintf(r.writer, "    Severity: %s\n", v.Severity.OrDefault())
 	fmt.Fprintf(r.writer, "    Message: %s\n", v.Message)
 	if v.Description != "" {
 		fmt.Fprintf(r.writer, "    Description: %s\n", v.Description)
 	}
 }
 
+// resourceName returns the full address for plan and state resources and
+// type.name (data.type.name for data sources) for resources parsed from .tf files
+func resourceName(resource parser.Resource) string {
+	if resource.Address != "" {
+		return resource.Address
+	}
+	if resource.IsDataSource() {
+		return "data." + resource.Type + "." + resource.Name
+	}
+	return resource.Type + "." + resource.Name
+}
+
 func (r *Reporter) ReportSummary(violations []validator.Violation) error {
 	if len(violations) == 0 {
 		return nil
 	}
 
-	// Count violations by rule
-	violationsByRule := make(map[string]int)
+	fmt.Fprintln(r.writer, strings.Repeat("-", 50))
+	fmt.Fprintln(r.writer, "Summary:")
+	fmt.Fprintf(r.writer, "Total violations: %d\n", len(violations))
+	fmt.Fprintln(r.writer, "\nViolations by rule:")
+	r.reportRuleCounts(violations, "  ")
+	fmt.Fprintln(r.writer, "\nViolations by severity:")
+	r.reportSeverityCounts(violations, "  ")
+
+	return nil
+}
+
+// ReportWorkspaces reports violations from several plans, grouped by the plan
+// (workspace) each resource was read from. Plans without violations are listed too.
+func (r *Reporter) ReportWorkspaces(workspaces []string, violations []validator.Violation) error {
+	if len(violations) == 0 {
+		fmt.Fprintf(r.writer, "✅ No tag violations found in %d plan(s)!\n", len(workspaces))
+		return nil
+	}
+
+	violationsByWorkspace := make(map[string][]validator.Violation)
 	for _, v := range violations {
-		violationsByRule[v.Rule]++
+		violationsByWorkspace[v.Resource.Workspace] = append(violationsByWorkspace[v.Resource.Workspace], v)
+	}
+
+	fmt.Fprintf(r.writer, "❌ Found %d tag violation(s) across %d plan(s):\n\n", len(violations), len(workspaces))
+
+	for _, workspace := range sortedWorkspaces(workspaces) {
+		workspaceViolations := violationsByWorkspace[workspace]
+		if len(workspaceViolations) == 0 {
+			fmt.Fprintf(r.writer, "🗂️  %s: ✅ no violations\n\n", workspace)
+			continue
+		}
+
+		fmt.Fprintf(r.writer, "🗂️  %s: %d violation(s)\n", workspace, len(workspaceViolations))
+		for _, v := range workspaceViolations {
+			r.reportViolation(v)
+		}
+		fmt.Fprintln(r.writer)
+	}
+
+	return nil
+}
+
+// ReportWorkspacesSummary prints violation counts per plan and per rule, followed
+// by the combined totals
+func (r *Reporter) ReportWorkspacesSummary(workspaces []string, violations []validator.Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	violationsByWorkspace := make(map[string][]validator.Violation)
+	for _, v := range violations {
+		violationsByWorkspace[v.Resource.Workspace] = append(violationsByWorkspace[v.Resource.Workspace], v)
 	}
 
 	fmt.Fprintln(r.writer, strings.Repeat("-", 50))
 	fmt.Fprintln(r.writer, "Summary:")
-	fmt.Fprintf(r.writer, "Total violations: %d\n", len(violations))
+	fmt.Fprintf(r.writer, "Total violations: %d (%d plans)\n", len(violations), len(workspaces))
+	fmt.Fprintln(r.writer, "\nViolations by plan:")
+
+	for _, workspace := range sortedWorkspaces(workspaces) {
+		workspaceViolations := violationsByWorkspace[workspace]
+		fmt.Fprintf(r.writer, "  %s: %d\n", workspace, len(workspaceViolations))
+		r.reportRuleCounts(workspaceViolations, "    ")
+	}
+
 	fmt.Fprintln(r.writer, "\nViolations by rule:")
+	r.reportRuleCounts(violations, "  ")
+	fmt.Fprintln(r.writer, "\nViolations by severity:")
+	r.reportSeverityCounts(violations, "  ")
+
+	return nil
+}
+
+// reportRuleCounts prints the number of violations per rule, sorted by rule name
+func (r *Reporter) reportRuleCounts(violations []validator.Violation, indent string) {
+	violationsByRule := make(map[string]int)
+	for _, v := range violations {
+		violationsByRule[v.Rule]++
+	}
 
-	// Sort rules for consistent output
 	var rules []string
 	for rule := range violationsByRule {
 		rules = append(rules, rule)
@@ -90,8 +176,184 @@ func (r *Reporter) ReportSummary(violations []validator.Violation) error {
 	sort.Strings(rules)
 
 	for _, rule := range rules {
-		fmt.Fprintf(r.writer, "  %s: %d\n", rule, violationsByRule[rule])
+		fmt.Fprintf(r.writer, "%s%s: %d\n", indent, rule, violationsByRule[rule])
+	}
+}
+
+// reportSeverityCounts prints the number of violations per severity, most
+// severe first, skipping severities without violations
+func (r *Reporter) reportSeverityCounts(violations []validator.Violation, indent string) {
+	violationsBySeverity := make(map[config.Severity]int)
+	for _, v := range violations {
+		violationsBySeverity[v.Severity.OrDefault()]++
+	}
+
+	for _, severity := range []config.Severity{config.SeverityError, config.SeverityWarning, config.SeverityInfo} {
+		if count := violationsBySeverity[severity]; count > 0 {
+			fmt.Fprintf(r.writer, "%s%s: %d\n", indent, severity, count)
+		}
+	}
+}
+
+func sortedWorkspaces(workspaces []string) []string {
+	sorted := append([]string(nil), workspaces...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// ReportDrift reports resources whose tags were changed outside Terraform and
+// whether the out-of-band tags violate the rules
+func (r *Reporter) ReportDrift(drifts []validator.Drift) error {
+	if len(drifts) == 0 {
+		fmt.Fprintln(r.writer, "✅ No tag drift found!")
+		return nil
+	}
+
+	driftsByFile := make(map[string][]validator.Drift)
+	for _, d := range drifts {
+		driftsByFile[d.Resource.File] = append(driftsByFile[d.Resource.File], d)
+	}
+
+	var files []string
+	for file := range driftsByFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	fmt.Fprintf(r.writer, "⚠️  Found tag drift on %d resource(s):\n\n", len(drifts))
+
+	for _, file := range files {
+		fmt.Fprintf(r.writer, "📄 %s\n", file)
+		for _, d := range driftsByFile[file] {
+			fmt.Fprintf(r.writer, "  %s\n", resourceName(d.Resource))
+			r.reportTagDiff(d.Diff, "    ")
+			if len(d.Violations) == 0 {
+				fmt.Fprintln(r.writer, "    ✅ Out-of-band tags satisfy all rules")
+				continue
+			}
+			fmt.Fprintf(r.writer, "    ❌ Out-of-band tags have %d violation(s):\n", len(d.Violations))
+			for _, v := range d.Violations {
+				fmt.Fprintf(r.writer, "      [%s] %s: %s\n", v.Severity.OrDefault(), v.Rule, v.Message)
+			}
+		}
+		fmt.Fprintln(r.writer)
+	}
+
+	return nil
+}
+
+// ReportTagChanges reports resources whose tags are changed by a plan, along
+// with violations for protected tags that are removed or modified
+func (r *Reporter) ReportTagChanges(changes []validator.TagChange) error {
+	if len(changes) == 0 {
+		fmt.Fprintln(r.writer, "✅ No tag changes found!")
+		return nil
+	}
+
+	changesByFile := make(map[string][]validator.TagChange)
+	violationCount := 0
+	for _, c := range changes {
+		changesByFile[c.Resource.File] = append(changesByFile[c.Resource.File], c)
+		violationCount += len(c.Violations)
+	}
+
+	var files []string
+	for file := range changesByFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	fmt.Fprintf(r.writer, "📝 Found tag changes on %d resource(s):\n\n", len(changes))
+
+	for _, file := range files {
+		fmt.Fprintf(r.writer, "📄 %s\n", file)
+		for _, c := range changesByFile[file] {
+			fmt.Fprintf(r.writer, "  %s\n", resourceName(c.Resource))
+			r.reportTagDiff(c.Diff, "    ")
+			for _, v := range c.Violations {
+				fmt.Fprintf(r.writer, "    ❌ [%s] %s: %s\n", v.Severity.OrDefault(), v.Rule, v.Message)
+			}
+		}
+		fmt.Fprintln(r.writer)
+	}
+
+	if violationCount > 0 {
+		fmt.Fprintf(r.writer, "❌ Found %d protected tag violation(s)\n", violationCount)
+	}
+
+	return nil
+}
+
+// ReportResourceDiffs reports the tag differences per resource between two
+// plans or states
+func (r *Reporter) ReportResourceDiffs(before, after string, diffs []tagdiff.ResourceDiff) error {
+	fmt.Fprintf(r.writer, "Comparing tags: %s → %s\n\n", before, after)
+
+	if len(diffs) == 0 {
+		fmt.Fprintln(r.writer, "✅ No tag differences found!")
+		return nil
+	}
+
+	for _, d := range diffs {
+		switch d.Status {
+		case tagdiff.StatusAdded:
+			fmt.Fprintf(r.writer, "+ %s (new resource)\n", d.Address)
+		case tagdiff.StatusRemoved:
+			fmt.Fprintf(r.writer, "- %s (removed resource)\n", d.Address)
+		default:
+			if d.PreviousAddress != "" {
+				fmt.Fprintf(r.writer, "~ %s (moved from %s)\n", d.Address, d.PreviousAddress)
+			} else {
+				fmt.Fprintf(r.writer, "~ %s\n", d.Address)
+			}
+		}
+		r.reportTagDiff(d.Diff, "    ")
 	}
 
+	fmt.Fprintf(r.writer, "\n📝 Found tag differences on %d resource(s)\n", len(diffs))
 	return nil
-}
\ No newline at end of file
+}
+
+// ReportResourceDiffsJSON writes the tag differences between two plans or
+// states as a JSON document
+func (r *Reporter) ReportResourceDiffsJSON(before, after string, diffs []tagdiff.ResourceDiff) error {
+	resources := make([]tagdiff.ResourceDiff, len(diffs))
+	for i, d := range diffs {
+		// Emit empty lists rather than null
+		if d.Added == nil {
+			d.Added = []tagdiff.Change{}
+		}
+		if d.Removed == nil {
+			d.Removed = []tagdiff.Change{}
+		}
+		if d.Modified == nil {
+			d.Modified = []tagdiff.Change{}
+		}
+		resources[i] = d
+	}
+
+	encoder := json.NewEncoder(r.writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Before    string                 `json:"before"`
+		After     string                 `json:"after"`
+		Resources []tagdiff.ResourceDiff `json:"resources"`
+	}{
+		Before:    before,
+		After:     after,
+		Resources: resources,
+	})
+}
+
+// reportTagDiff prints added (+), removed (-) and modified (~) tag keys
+func (r *Reporter) reportTagDiff(diff tagdiff.Diff, indent string) {
+	for _, c := range diff.Added {
+		fmt.Fprintf(r.writer, "%s+ %s = %q\n", indent, c.Key, c.After)
+	}
+	for _, c := range diff.Removed {
+		fmt.Fprintf(r.writer, "%s- %s (was %q)\n", indent, c.Key, c.Before)
+	}
+	for _, c := range diff.Modified {
+		fmt.Fprintf(r.writer, "%s~ %s: %q → %q\n", indent, c.Key, c.Before, c.After)
+	}
+}
@@ -2,11 +2,14 @@ package reporter
 
 import (
 	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/tom-023/tftaglint/internal/config"
 	"github.com/tom-023/tftaglint/internal/parser"
+	"github.com/tom-023/tftaglint/internal/tagdiff"
 	"github.com/tom-023/tftaglint/internal/validator"
 )
 
@@ -265,6 +268,17 @@ func TestReportSummary(t *testing.T) {
 				"m-rule: 1",
 				"z-rule: 1",
 			},
+		},		{
+			name: "violations by severity",
+			violations: []validator.Violation{
+				{Rule: "owner", Severity: config.SeverityWarning},
+				{Rule: "owner", Severity: config.SeverityWarning},
+				{Rule: "env"},
+				{Rule: "docs", Severity: config.SeverityInfo},
+			},
+			wantOutput: []string{
+				"Violations by severity:\n  error: 1\n  warning: 2\n  info: 1\n",
+			},
 		},
 	}
 
@@ -336,9 +350,43 @@ func TestReportViolation(t *testing.T) {
 			wantOutput: []string{
 				"Line 10: aws_s3_bucket.data",
 				"Rule: test-rule",
+				"Severity: error",
 				"Message: Missing tag",
 			},
 		},
+		{
+			name: "warning violation",
+			violation: validator.Violation{
+				Rule:     "test-rule",
+				Severity: config.SeverityWarning,
+				Resource: parser.Resource{
+					Type: "aws_s3_bucket",
+					Name: "data",
+				},
+				Message: "Missing tag",
+			},
+			wantOutput: []string{
+				"Severity: warning",
+			},
+		},
+		{
+			name: "violation with full address",
+			violation: validator.Violation{
+				Rule: "test-rule",
+				Resource: parser.Resource{
+					Type:    "aws_subnet",
+					Name:    "private",
+					Address: `module.network.aws_subnet.private["a"]`,
+					Location: hcl.Range{
+						Start: hcl.Pos{Line: 1},
+					},
+				},
+				Message: "Missing tag",
+			},
+			wantOutput: []string{
+				`Line 1: module.network.aws_subnet.private["a"]`,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -359,6 +407,312 @@ func TestReportViolation(t *testing.T) {
 	}
 }
 
+func TestReportWorkspaces(t *testing.T) {
+	violation := func(workspace, rule string) validator.Violation {
+		return validator.Violation{
+			Rule: rule,
+			Resource: parser.Resource{
+				Type:      "aws_instance",
+				Name:      "web",
+				Address:   "aws_instance.web",
+				File:      workspace,
+				Workspace: workspace,
+			},
+			Message: "Missing required tag: Owner",
+		}
+	}
+
+	tests := []struct {
+		name       string
+		workspaces []string
+		violations []validator.Violation
+		summary    bool
+		wantOutput []string
+	}{
+		{
+			name:       "no violations",
+			workspaces: []string{"dev.json", "prod.json"},
+			wantOutput: []string{"✅ No tag violations found in 2 plan(s)!"},
+		},
+		{
+			name:       "violations grouped per plan",
+			workspaces: []string{"prod.json", "dev.json"},
+			violations: []validator.Violation{
+				violation("prod.json", "owner-required"),
+				violation("prod.json", "global-required-tags"),
+			},
+			wantOutput: []string{
+				"❌ Found 2 tag violation(s) across 2 plan(s):",
+				"🗂️  dev.json: ✅ no violations\n\n🗂️  prod.json: 2 violation(s)",
+				"Line 0: aws_instance.web",
+			},
+		},
+		{
+			name:       "summary per plan and combined",
+			workspaces: []string{"dev.json", "prod.json"},
+			violations: []validator.Violation{
+				violation("dev.json", "owner-required"),
+				violation("prod.json", "owner-required"),
+				violation("prod.json", "global-required-tags"),
+			},
+			summary: true,
+			wantOutput: []string{
+				"Total violations: 3 (2 plans)",
+				"  dev.json: 1\n    owner-required: 1\n",
+				"  prod.json: 2\n    global-required-tags: 1\n    owner-required: 1\n",
+				"Violations by rule:\n  global-required-tags: 1\n  owner-required: 2\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			reporter := NewReporter(&buf)
+
+			var err error
+			if tt.summary {
+				err = reporter.ReportWorkspacesSummary(tt.workspaces, tt.violations)
+			} else {
+				err = reporter.ReportWorkspaces(tt.workspaces, tt.violations)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, but it doesn't.\nOutput:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestReportDrift(t *testing.T) {
+	tests := []struct {
+		name       string
+		drifts     []validator.Drift
+		wantOutput []string
+	}{
+		{
+			name:       "no drift",
+			wantOutput: []string{"✅ No tag drift found!"},
+		},
+		{
+			name: "drift with and without violations",
+			drifts: []validator.Drift{
+				{
+					Resource: parser.Resource{Address: "aws_instance.web", File: "plan.json"},
+					Diff: tagdiff.Diff{
+						Added:    []tagdiff.Change{{Key: "Temp", After: "yes"}},
+						Removed:  []tagdiff.Change{{Key: "CostCenter", Before: "CC-1"}},
+						Modified: []tagdiff.Change{{Key: "Owner", Before: "team-a", After: "team-b"}},
+					},
+					Violations: []validator.Violation{
+						{Rule: "global-required-tags", Message: "Missing required tag: CostCenter"},
+					},
+				},
+				{
+					Resource: parser.Resource{Address: "aws_s3_bucket.logs", File: "plan.json"},
+					Diff: tagdiff.Diff{
+						Added: []tagdiff.Change{{Key: "Note", After: "hi"}},
+					},
+				},
+			},
+			wantOutput: []string{
+				"⚠️  Found tag drift on 2 resource(s):",
+				"📄 plan.json",
+				"  aws_instance.web\n",
+				`+ Temp = "yes"`,
+				`- CostCenter (was "CC-1")`,
+				`~ Owner: "team-a" → "team-b"`,
+				"❌ Out-of-band tags have 1 violation(s):",
+				"global-required-tags: Missing required tag: CostCenter",
+				"  aws_s3_bucket.logs\n",
+				"✅ Out-of-band tags satisfy all rules",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			reporter := NewReporter(&buf)
+
+			if err := reporter.ReportDrift(tt.drifts); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, but it doesn't.\nOutput:\n%s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestReportTagChanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		changes    []validator.TagChange
+		wantOutput []string
+		notWant    []string
+	}{
+		{
+			name:       "no changes",
+			wantOutput: []string{"✅ No tag changes found!"},
+		},
+		{
+			name: "changes with protected tag violations",
+			changes: []validator.TagChange{
+				{
+					Resource: parser.Resource{Address: "aws_instance.web", File: "plan.json"},
+					Diff: tagdiff.Diff{
+						Removed: []tagdiff.Change{{Key: "CostCenter", Before: "CC-1"}},
+					},
+					Violations: []validator.Violation{
+						{Rule: "finance-tags", Message: "Protected tag removed: CostCenter (was 'CC-1')"},
+					},
+				},
+				{
+					Resource: parser.Resource{Address: "aws_instance.db", File: "plan.json"},
+					Diff: tagdiff.Diff{
+						Modified: []tagdiff.Change{{Key: "Name", Before: "db", After: "db-1"}},
+					},
+				},
+			},
+			wantOutput: []string{
+				"📝 Found tag changes on 2 resource(s):",
+				"  aws_instance.web\n    - CostCenter (was \"CC-1\")\n    ❌ [error] finance-tags: Protected tag removed: CostCenter (was 'CC-1')",
+				"  aws_instance.db\n    ~ Name: \"db\" → \"db-1\"",
+				"❌ Found 1 protected tag violation(s)",
+			},
+		},
+		{
+			name: "changes without violations",
+			changes: []validator.TagChange{
+				{
+					Resource: parser.Resource{Address: "aws_instance.db", File: "plan.json"},
+					Diff: tagdiff.Diff{
+						Modified: []tagdiff.Change{{Key: "Name", Before: "db", After: "db-1"}},
+					},
+				},
+			},
+			notWant: []string{"protected tag violation"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			reporter := NewReporter(&buf)
+
+			if err := reporter.ReportTagChanges(tt.changes); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			output := buf.String()
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Expected output to contain %q, but it doesn't.\nOutput:\n%s", want, output)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(output, notWant) {
+					t.Errorf("Expected output not to contain %q, but it does.\nOutput:\n%s", notWant, output)
+				}
+			}
+		})
+	}
+}
+
+func TestReportResourceDiffs(t *testing.T) {
+	diffs := []tagdiff.ResourceDiff{
+		{
+			Address: "aws_instance.new",
+			Status:  tagdiff.StatusAdded,
+			Diff:    tagdiff.Diff{Added: []tagdiff.Change{{Key: "Name", After: "new"}}},
+		},
+		{
+			Address:         "module.storage.aws_s3_bucket.logs",
+			PreviousAddress: "aws_s3_bucket.logs",
+			Status:          tagdiff.StatusChanged,
+			Diff:            tagdiff.Diff{Modified: []tagdiff.Change{{Key: "Tier", Before: "hot", After: "cold"}}},
+		},
+		{
+			Address: "aws_instance.old",
+			Status:  tagdiff.StatusRemoved,
+			Diff:    tagdiff.Diff{Removed: []tagdiff.Change{{Key: "Name", Before: "old"}}},
+		},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := NewReporter(&buf).ReportResourceDiffs("a.json", "b.json", diffs); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		output := buf.String()
+		for _, want := range []string{
+			"Comparing tags: a.json → b.json",
+			"+ aws_instance.new (new resource)\n    + Name = \"new\"",
+			"~ module.storage.aws_s3_bucket.logs (moved from aws_s3_bucket.logs)\n    ~ Tier: \"hot\" → \"cold\"",
+			"- aws_instance.old (removed resource)\n    - Name (was \"old\")",
+			"Found tag differences on 3 resource(s)",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("Expected output to contain %q, but it doesn't.\nOutput:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("text without differences", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := NewReporter(&buf).ReportResourceDiffs("a.json", "b.json", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(buf.String(), "✅ No tag differences found!") {
+			t.Errorf("Unexpected output:\n%s", buf.String())
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := NewReporter(&buf).ReportResourceDiffsJSON("a.json", "b.json", diffs); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var got struct {
+			Before    string `json:"before"`
+			After     string `json:"after"`
+			Resources []struct {
+				Address         string           `json:"address"`
+				PreviousAddress string           `json:"previous_address"`
+				Status          string           `json:"status"`
+				Added           []tagdiff.Change `json:"added"`
+				Removed         []tagdiff.Change `json:"removed"`
+				Changed         []tagdiff.Change `json:"changed"`
+			} `json:"resources"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("Invalid JSON output: %v\n%s", err, buf.String())
+		}
+		if got.Before != "a.json" || got.After != "b.json" || len(got.Resources) != 3 {
+			t.Fatalf("Unexpected JSON output:\n%s", buf.String())
+		}
+		moved := got.Resources[1]
+		if moved.PreviousAddress != "aws_s3_bucket.logs" || moved.Status != "changed" || len(moved.Changed) != 1 {
+			t.Errorf("Unexpected moved resource: %+v", moved)
+		}
+		if !strings.Contains(buf.String(), `"added": []`) {
+			t.Errorf("Expected empty lists instead of null:\n%s", buf.String())
+		}
+	})
+}
+
 func TestNewReporter(t *testing.T) {
 	var buf bytes.Buffer
 	reporter := NewReporter(&buf)
@@ -0,0 +1,148 @@
+package tagdiff
+
+import (
+	"sort"
+
+	"github.com/tom-023/tftaglint/internal/parser"
+)
+
+// Change describes a single tag key that differs between two tag sets.
+// Before is empty for added keys and After is empty for removed keys.
+type Change struct {
+	Key    string `json:"key"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Diff holds the tag keys added, removed and modified between two tag sets,
+// each sorted by key
+type Diff struct {
+	Added    []Change `json:"added"`
+	Removed  []Change `json:"removed"`
+	Modified []Change `json:"changed"`
+}
+
+// Compare returns the differences going from the before tags to the after tags
+func Compare(before, after map[string]string) Diff {
+	var diff Diff
+
+	for key, afterValue := range after {
+		beforeValue, exists := before[key]
+		if !exists {
+			diff.Added = append(diff.Added, Change{Key: key, After: afterValue})
+		} else if beforeValue != afterValue {
+			diff.Modified = append(diff.Modified, Change{Key: key, Before: beforeValue, After: afterValue})
+		}
+	}
+
+	for key, beforeValue := range before {
+		if _, exists := after[key]; !exists {
+			diff.Removed = append(diff.Removed, Change{Key: key, Before: beforeValue})
+		}
+	}
+
+	sortChanges(diff.Added)
+	sortChanges(diff.Removed)
+	sortChanges(diff.Modified)
+
+	return diff
+}
+
+// IsEmpty reports whether the two tag sets were identical
+func (d Diff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// Resource status values used in ResourceDiff
+const (
+	StatusAdded   = "added"
+	StatusRemoved = "removed"
+	StatusChanged = "changed"
+)
+
+// ResourceDiff describes how a resource's tags differ between two snapshots
+type ResourceDiff struct {
+	Address         string `json:"address"`
+	PreviousAddress string `json:"previous_address,omitempty"` // Set when the resource was moved
+	Status          string `json:"status"`
+	Diff
+}
+
+// CompareResources matches the before and after resources by address and returns
+// the resources whose tags differ, sorted by address. moved maps new addresses to
+// the previous addresses recorded for moved resources. Resources only present on
+// one side are reported as added or removed with all their tags.
+func CompareResources(before, after []parser.Resource, moved map[string]string) []ResourceDiff {
+	beforeByAddress := make(map[string]parser.Resource)
+	for _, resource := range before {
+		beforeByAddress[address(resource)] = resource
+	}
+
+	var diffs []ResourceDiff
+	matched := make(map[string]bool)
+
+	for _, resource := range after {
+		addr := address(resource)
+		previous := addr
+		if from, ok := moved[addr]; ok {
+			previous = from
+		}
+
+		old, exists := beforeByAddress[previous]
+		if !exists {
+			diffs = append(diffs, ResourceDiff{
+				Address: addr,
+				Status:  StatusAdded,
+				Diff:    Compare(nil, resource.Tags),
+			})
+			continue
+		}
+		matched[previous] = true
+
+		diff := Compare(old.Tags, resource.Tags)
+		if diff.IsEmpty() {
+			continue
+		}
+		resourceDiff := ResourceDiff{
+			Address: addr,
+			Status:  StatusChanged,
+			Diff:    diff,
+		}
+		if previous != addr {
+			resourceDiff.PreviousAddress = previous
+		}
+		diffs = append(diffs, resourceDiff)
+	}
+
+	for _, resource := range before {
+		addr := address(resource)
+		if !matched[addr] {
+			diffs = append(diffs, ResourceDiff{
+				Address: addr,
+				Status:  StatusRemoved,
+				Diff:    Compare(resource.Tags, nil),
+			})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Address < diffs[j].Address
+	})
+
+	return diffs
+}
+
+// address returns the resource address, falling back to type.name for
+// resources without one
+func address(resource parser.Resource) string {
+	if resource.Address != "" {
+		return resource.Address
+	}
+	return resource.Type + "." + resource.Name
+}
+
+func sortChanges(changes []Change) {
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+}
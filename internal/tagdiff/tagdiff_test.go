@@ -0,0 +1,119 @@
+package tagdiff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tom-023/tftaglint/internal/parser"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		before map[string]string
+		after  map[string]string
+		want   Diff
+	}{
+		{
+			name:   "identical tags",
+			before: map[string]string{"Name": "web"},
+			after:  map[string]string{"Name": "web"},
+			want:   Diff{},
+		},
+		{
+			name:   "added, removed and modified keys",
+			before: map[string]string{"Name": "web", "Owner": "team-a", "CostCenter": "CC-1"},
+			after:  map[string]string{"Name": "web", "Owner": "team-b", "Temp": "yes", "Debug": "1"},
+			want: Diff{
+				Added: []Change{
+					{Key: "Debug", After: "1"},
+					{Key: "Temp", After: "yes"},
+				},
+				Removed: []Change{
+					{Key: "CostCenter", Before: "CC-1"},
+				},
+				Modified: []Change{
+					{Key: "Owner", Before: "team-a", After: "team-b"},
+				},
+			},
+		},
+		{
+			name:   "nil before",
+			before: nil,
+			after:  map[string]string{"Name": "web"},
+			want: Diff{
+				Added: []Change{{Key: "Name", After: "web"}},
+			},
+		},
+		{
+			name:   "empty value is still present",
+			before: map[string]string{"Name": ""},
+			after:  map[string]string{},
+			want: Diff{
+				Removed: []Change{{Key: "Name"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compare(tt.before, tt.after)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Compare() = %+v, want %+v", got, tt.want)
+			}
+			if got.IsEmpty() != reflect.DeepEqual(tt.want, Diff{}) {
+				t.Errorf("IsEmpty() = %v", got.IsEmpty())
+			}
+		})
+	}
+}
+
+func TestCompareResources(t *testing.T) {
+	before := []parser.Resource{
+		{Address: "aws_instance.web", Tags: map[string]string{"Name": "web", "Owner": "a"}},
+		{Address: "aws_s3_bucket.logs", Tags: map[string]string{"Name": "logs"}},
+		{Address: "aws_vpc.main", Tags: map[string]string{"Name": "main"}},
+		{Address: "aws_instance.old", Tags: map[string]string{"Name": "old"}},
+		{Type: "aws_instance", Name: "unchanged", Tags: map[string]string{"Name": "same"}},
+	}
+	after := []parser.Resource{
+		{Address: "aws_instance.web", Tags: map[string]string{"Name": "web", "Owner": "b"}},
+		{Address: "module.storage.aws_s3_bucket.logs", Tags: map[string]string{"Name": "logs", "Tier": "cold"}},
+		{Address: "module.network.aws_vpc.main", Tags: map[string]string{"Name": "main"}},
+		{Address: "aws_instance.new", Tags: map[string]string{"Name": "new"}},
+		{Address: "aws_instance.unchanged", Tags: map[string]string{"Name": "same"}},
+	}
+	moved := map[string]string{
+		"module.storage.aws_s3_bucket.logs": "aws_s3_bucket.logs",
+		"module.network.aws_vpc.main":       "aws_vpc.main",
+	}
+
+	got := CompareResources(before, after, moved)
+	want := []ResourceDiff{
+		{
+			Address: "aws_instance.new",
+			Status:  StatusAdded,
+			Diff:    Diff{Added: []Change{{Key: "Name", After: "new"}}},
+		},
+		{
+			Address: "aws_instance.old",
+			Status:  StatusRemoved,
+			Diff:    Diff{Removed: []Change{{Key: "Name", Before: "old"}}},
+		},
+		{
+			Address: "aws_instance.web",
+			Status:  StatusChanged,
+			Diff:    Diff{Modified: []Change{{Key: "Owner", Before: "a", After: "b"}}},
+		},
+		{
+			Address:         "module.storage.aws_s3_bucket.logs",
+			PreviousAddress: "aws_s3_bucket.logs",
+			Status:          StatusChanged,
+			Diff:            Diff{Added: []Change{{Key: "Tier", After: "cold"}}},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareResources() =\n%+v\nwant\n%+v", got, want)
+	}
+}
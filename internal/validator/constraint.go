@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tom-023/tftaglint/internal/config"
+)
+
+// checkConstraint returns a violation message for every check of the
+// constraint that the tag value fails
+func (v *Validator) checkConstraint(constraint config.TagConstraint, value string) []string {
+	var messages []string
+
+	if message := v.checkAllowedValues(constraint, value); message != "" {
+		messages = append(messages, message)
+	}
+
+	if reason := v.checkValueType(constraint, value); reason != "" {
+		messages = append(messages, fmt.Sprintf("Invalid value for tag %s: '%s' %s",
+			constraint.Tag, value, reason))
+	}
+
+	if constraint.PatternRegex != nil && !constraint.PatternRegex.MatchString(value) {
+		messages = append(messages, fmt.Sprintf("Invalid value for tag %s: '%s' does not match pattern %s",
+			constraint.Tag, value, constraint.Pattern))
+	}
+
+	if constraint.NotPatternRegex != nil && constraint.NotPatternRegex.MatchString(value) {
+		messages = append(messages, fmt.Sprintf("Invalid value for tag %s: '%s' matches forbidden pattern %s",
+			constraint.Tag, value, constraint.NotPattern))
+	}
+
+	length := utf8.RuneCountInString(value)
+	if constraint.MinLength > 0 && length < constraint.MinLength {
+		messages = append(messages, fmt.Sprintf("Invalid value for tag %s: '%s' is shorter than %d characters",
+			constraint.Tag, value, constraint.MinLength))
+	}
+	if constraint.MaxLength > 0 && length > constraint.MaxLength {
+		messages = append(messages, fmt.Sprintf("Invalid value for tag %s: '%s' is longer than %d characters",
+			constraint.Tag, value, constraint.MaxLength))
+	}
+
+	if !constraint.Case.Match(value) {
+		messages = append(messages, fmt.Sprintf("Invalid value for tag %s: '%s' is not %s",
+			constraint.Tag, value, constraint.Case))
+	}
+
+	return messages
+}
+
+// checkAllowedValues returns a violation message if the constraint lists
+// allowed values, inline or in a file, and value is not one of them
+func (v *Validator) checkAllowedValues(constraint config.TagConstraint, value string) string {
+	file := constraint.AllowedValuesFile
+	if len(constraint.AllowedValues) == 0 && file == nil {
+		return ""
+	}
+	if v.isValueAllowed(value, constraint.AllowedValues) || file.Contains(value) {
+		return ""
+	}
+
+	var message string
+	candidates := constraint.AllowedValues
+	if file == nil {
+		message = fmt.Sprintf("Invalid value for tag %s: '%s'. Allowed values: %s",
+			constraint.Tag, value, strings.Join(constraint.AllowedValues, ", "))
+	} else {
+		message = fmt.Sprintf("Invalid value for tag %s: '%s' is not listed in %s", constraint.Tag, value, file.Path)
+		if file.Values != nil {
+			candidates = append(append([]string(nil), candidates...), file.Values.Values...)
+		}
+	}
+
+	if match := config.ClosestMatch(value, candidates); match != "" {
+		message += fmt.Sprintf(". Did you mean '%s'?", match)
+	}
+	return message
+}
@@ -0,0 +1,152 @@
+package validator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/tom-023/tftaglint/internal/config"
+)
+
+func TestCheckConstraint(t *testing.T) {
+	v := &Validator{}
+
+	tests := []struct {
+		name         string
+		constraint   config.TagConstraint
+		value        string
+		wantMessages []string
+	}{
+		{
+			name: "pattern matched",
+			constraint: config.TagConstraint{
+				Tag:          "CostCenter",
+				Pattern:      "^CC-[0-9]{5}$",
+				PatternRegex: regexp.MustCompile("^CC-[0-9]{5}$"),
+			},
+			value: "CC-12345",
+		},
+		{
+			name: "pattern not matched",
+			constraint: config.TagConstraint{
+				Tag:          "CostCenter",
+				Pattern:      "^CC-[0-9]{5}$",
+				PatternRegex: regexp.MustCompile("^CC-[0-9]{5}$"),
+			},
+			value:        "CC-123",
+			wantMessages: []string{"Invalid value for tag CostCenter: 'CC-123' does not match pattern ^CC-[0-9]{5}$"},
+		},
+		{
+			name: "forbidden pattern",
+			constraint: config.TagConstraint{
+				Tag:             "Owner",
+				NotPattern:      "(?i)^(tbd|todo)$",
+				NotPatternRegex: regexp.MustCompile("(?i)^(tbd|todo)$"),
+			},
+			value:        "TBD",
+			wantMessages: []string{"Invalid value for tag Owner: 'TBD' matches forbidden pattern (?i)^(tbd|todo)$"},
+		},
+		{
+			name:         "too short",
+			constraint:   config.TagConstraint{Tag: "Name", MinLength: 3},
+			value:        "ab",
+			wantMessages: []string{"is shorter than 3 characters"},
+		},
+		{
+			name:         "too long counts characters",
+			constraint:   config.TagConstraint{Tag: "Name", MaxLength: 4},
+			value:        "日本語です",
+			wantMessages: []string{"is longer than 4 characters"},
+		},
+		{
+			name:       "length within bounds",
+			constraint: config.TagConstraint{Tag: "Name", MinLength: 2, MaxLength: 4},
+			value:      "日本語",
+		},
+		{
+			name:         "case style",
+			constraint:   config.TagConstraint{Tag: "Team", Case: config.CaseKebab},
+			value:        "Platform_Core",
+			wantMessages: []string{"Invalid value for tag Team: 'Platform_Core' is not kebab-case"},
+		},
+		{
+			name: "several failed checks",
+			constraint: config.TagConstraint{
+				Tag:           "Environment",
+				AllowedValues: []string{"dev", "prod"},
+				Case:          config.CaseLower,
+			},
+			value: "PROD",
+			wantMessages: []string{
+				"Allowed values: dev, prod",
+				"is not lowercase",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := v.checkConstraint(tt.constraint, tt.value)
+			if len(messages) != len(tt.wantMessages) {
+				t.Fatalf("Expected %d messages, got %d: %v", len(tt.wantMessages), len(messages), messages)
+			}
+			for i, want := range tt.wantMessages {
+				if !strings.Contains(messages[i], want) {
+					t.Errorf("Expected message containing %q, got %q", want, messages[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckAllowedValues(t *testing.T) {
+	v := &Validator{}
+	costCenters := &config.ValuesFile{
+		Path:   "cost-centers.csv",
+		Values: config.NewValueList([]string{"CC-10001", "CC-10002", "CC-20001"}),
+	}
+
+	tests := []struct {
+		name       string
+		constraint config.TagConstraint
+		value      string
+		want       string
+	}{
+		{
+			name:       "value listed in file",
+			constraint: config.TagConstraint{Tag: "CostCenter", AllowedValuesFile: costCenters},
+			value:      "CC-20001",
+		},
+		{
+			name:       "value listed inline alongside file",
+			constraint: config.TagConstraint{Tag: "CostCenter", AllowedValues: []string{"SHARED"}, AllowedValuesFile: costCenters},
+			value:      "SHARED",
+		},
+		{
+			name:       "file value with suggestion",
+			constraint: config.TagConstraint{Tag: "CostCenter", AllowedValuesFile: costCenters},
+			value:      "CC-1001",
+			want:       "Invalid value for tag CostCenter: 'CC-1001' is not listed in cost-centers.csv. Did you mean 'CC-10001'?",
+		},
+		{
+			name:       "file value without close match",
+			constraint: config.TagConstraint{Tag: "CostCenter", AllowedValuesFile: costCenters},
+			value:      "marketing",
+			want:       "Invalid value for tag CostCenter: 'marketing' is not listed in cost-centers.csv",
+		},
+		{
+			name:       "inline value with suggestion",
+			constraint: config.TagConstraint{Tag: "Environment", AllowedValues: []string{"staging", "production"}},
+			value:      "prodution",
+			want:       "Invalid value for tag Environment: 'prodution'. Allowed values: staging, production. Did you mean 'production'?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.checkAllowedValues(tt.constraint, tt.value); got != tt.want {
+				t.Errorf("checkAllowedValues() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
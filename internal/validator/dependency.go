@@ -0,0 +1,38 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/tom-023/tftaglint/internal/config"
+	"github.com/tom-023/tftaglint/internal/parser"
+)
+
+// checkDependency returns a message for each tag the dependency requires that
+// the resource is missing, if the dependency is triggered
+func (v *Validator) checkDependency(resource parser.Resource, dependency config.Dependency) []string {
+	var reason string
+	if dependency.WhenPresent != "" {
+		value, exists := resource.Tags[dependency.WhenPresent]
+		switch {
+		case !exists || (dependency.Value != "" && value != dependency.Value):
+			return nil
+		case dependency.Value != "":
+			reason = fmt.Sprintf("%s is '%s'", dependency.WhenPresent, dependency.Value)
+		default:
+			reason = fmt.Sprintf("%s is present", dependency.WhenPresent)
+		}
+	} else {
+		if _, exists := resource.Tags[dependency.WhenAbsent]; exists {
+			return nil
+		}
+		reason = fmt.Sprintf("%s is absent", dependency.WhenAbsent)
+	}
+
+	var messages []string
+	for _, tag := range dependency.Require {
+		if _, exists := resource.Tags[tag]; !exists {
+			messages = append(messages, fmt.Sprintf("Missing required tag: %s (required when %s)", tag, reason))
+		}
+	}
+	return messages
+}
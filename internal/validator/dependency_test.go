@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tom-023/tftaglint/internal/config"
+	"github.com/tom-023/tftaglint/internal/parser"
+)
+
+func TestCheckDependency(t *testing.T) {
+	v := &Validator{}
+
+	tests := []struct {
+		name         string
+		dependency   config.Dependency
+		tags         map[string]string
+		wantMessages []string
+	}{
+		{
+			name:       "present trigger with required tag",
+			dependency: config.Dependency{WhenPresent: "BackupRequired", Require: []string{"BackupSchedule"}},
+			tags:       map[string]string{"BackupRequired": "yes", "BackupSchedule": "daily"},
+		},
+		{
+			name:         "present trigger without required tag",
+			dependency:   config.Dependency{WhenPresent: "BackupRequired", Require: []string{"BackupSchedule"}},
+			tags:         map[string]string{"BackupRequired": "yes"},
+			wantMessages: []string{"Missing required tag: BackupSchedule (required when BackupRequired is present)"},
+		},
+		{
+			name:       "absent trigger tag",
+			dependency: config.Dependency{WhenPresent: "BackupRequired", Require: []string{"BackupSchedule"}},
+			tags:       map[string]string{"Name": "web"},
+		},
+		{
+			name:       "trigger value",
+			dependency: config.Dependency{WhenPresent: "PII", Value: "true", Require: []string{"DataOwner", "RetentionDays"}},
+			tags:       map[string]string{"PII": "true", "RetentionDays": "30"},
+			wantMessages: []string{
+				"Missing required tag: DataOwner (required when PII is 'true')",
+			},
+		},
+		{
+			name:       "other trigger value",
+			dependency: config.Dependency{WhenPresent: "PII", Value: "true", Require: []string{"DataOwner", "RetentionDays"}},
+			tags:       map[string]string{"PII": "false"},
+		},
+		{
+			name:         "when absent",
+			dependency:   config.Dependency{WhenAbsent: "Owner", Require: []string{"Team"}},
+			tags:         map[string]string{"Name": "web"},
+			wantMessages: []string{"Missing required tag: Team (required when Owner is absent)"},
+		},
+		{
+			name:       "when absent with trigger present",
+			dependency: config.Dependency{WhenAbsent: "Owner", Require: []string{"Team"}},
+			tags:       map[string]string{"Owner": "alice"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := v.checkDependency(parser.Resource{Type: "aws_instance", Name: "web", Tags: tt.tags}, tt.dependency)
+			if !reflect.DeepEqual(got, tt.wantMessages) {
+				t.Errorf("checkDependency() = %q, want %q", got, tt.wantMessages)
+			}
+		})
+	}
+}
+
+func TestCheckRule_Dependencies(t *testing.T) {
+	v := NewValidator(&config.Config{})
+	rule := config.Rule{
+		Name: "backup",
+		Dependencies: []config.Dependency{
+			{WhenPresent: "BackupRequired", Require: []string{"BackupSchedule"}},
+			{WhenPresent: "BackupSchedule", Require: []string{"BackupRetention"}},
+		},
+	}
+
+	violations := v.checkRule(parser.Resource{Type: "aws_instance", Tags: map[string]string{"BackupRequired": "yes", "BackupSchedule": "daily"}}, rule)
+	if len(violations) != 1 || violations[0].Message != "Missing required tag: BackupRetention (required when BackupSchedule is present)" {
+		t.Errorf("Expected the chained dependency to be reported, got %+v", violations)
+	}
+}
@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tom-023/tftaglint/internal/config"
+	"github.com/tom-023/tftaglint/internal/parser"
+)
+
+// checkLookup checks that the resource's tag values form a row of the rule's
+// lookup table. Resources without the key tag are skipped, as are checked
+// tags the resource does not have.
+func (v *Validator) checkLookup(resource parser.Resource, rule config.Rule) []Violation {
+	lookup := rule.Lookup
+	key, exists := resource.Tags[lookup.Key]
+	if !exists {
+		return nil
+	}
+
+	rows := lookup.RowsFor(key)
+	if len(rows) == 0 {
+		message := fmt.Sprintf("Unknown value for tag %s: '%s' is not in the lookup table", lookup.Key, key)
+		if match := config.ClosestMatch(key, lookup.Keys()); match != "" {
+			message += fmt.Sprintf(". Did you mean '%s'?", match)
+		}
+		return []Violation{ruleViolation(rule, resource, message)}
+	}
+
+	// Check each tag against the values allowed for the key
+	var violations []Violation
+	var checked []string
+	for _, column := range lookup.CheckedColumns() {
+		value, exists := resource.Tags[column]
+		if !exists {
+			continue
+		}
+		checked = append(checked, column)
+
+		expected := columnValues(rows, column)
+		if !v.isValueAllowed(value, expected) {
+			violations = append(violations, ruleViolation(rule, resource, fmt.Sprintf("Invalid value for tag %s: '%s' does not match %s '%s'. Expected: %s",
+				column, value, lookup.Key, key, strings.Join(expected, ", "))))
+		}
+	}
+	if len(violations) > 0 || len(checked) < 2 {
+		return violations
+	}
+
+	// Every value is allowed on its own; the combination must also be a row
+	for _, row := range rows {
+		if rowMatches(row, resource.Tags, checked) {
+			return nil
+		}
+	}
+
+	pairs := []string{fmt.Sprintf("%s='%s'", lookup.Key, key)}
+	for _, column := range checked {
+		pairs = append(pairs, fmt.Sprintf("%s='%s'", column, resource.Tags[column]))
+	}
+	return []Violation{ruleViolation(rule, resource, fmt.Sprintf("Tag combination %s does not match any row of the lookup table", strings.Join(pairs, ", ")))}
+}
+
+// columnValues returns the distinct values of a column in the order they appear
+func columnValues(rows []map[string]string, column string) []string {
+	seen := make(map[string]bool)
+	var values []string
+	for _, row := range rows {
+		if value, ok := row[column]; ok && !seen[value] {
+			seen[value] = true
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func rowMatches(row, tags map[string]string, columns []string) bool {
+	for _, column := range columns {
+		if row[column] != tags[column] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/tom-023/tftaglint/internal/config"
+	"github.com/tom-023/tftaglint/internal/parser"
+)
+
+func TestCheckLookup(t *testing.T) {
+	rule := config.Rule{
+		Name: "team-ownership",
+		Lookup: &config.Lookup{
+			Key: "Team",
+			Rows: []map[string]string{
+				{"Team": "platform", "CostCenter": "CC-10001", "Owner": "alice@example.com"},
+				{"Team": "platform", "CostCenter": "CC-10001", "Owner": "bob@example.com"},
+				{"Team": "data", "CostCenter": "CC-20001", "Owner": "carol@example.com"},
+			},
+		},
+	}
+	v := &Validator{}
+
+	tests := []struct {
+		name         string
+		tags         map[string]string
+		wantMessages []string
+	}{
+		{
+			name: "valid row",
+			tags: map[string]string{"Team": "platform", "CostCenter": "CC-10001", "Owner": "bob@example.com"},
+		},
+		{
+			name: "without key tag",
+			tags: map[string]string{"CostCenter": "CC-99999"},
+		},
+		{
+			name: "missing checked tag is skipped",
+			tags: map[string]string{"Team": "data", "CostCenter": "CC-20001"},
+		},
+		{
+			name:         "unknown key with suggestion",
+			tags:         map[string]string{"Team": "platfrom"},
+			wantMessages: []string{"Unknown value for tag Team: 'platfrom' is not in the lookup table. Did you mean 'platform'?"},
+		},
+		{
+			name: "mismatched values report expected ones",
+			tags: map[string]string{"Team": "platform", "CostCenter": "CC-20001", "Owner": "carol@example.com"},
+			wantMessages: []string{
+				"Invalid value for tag CostCenter: 'CC-20001' does not match Team 'platform'. Expected: CC-10001",
+				"Invalid value for tag Owner: 'carol@example.com' does not match Team 'platform'. Expected: alice@example.com, bob@example.com",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := v.checkLookup(parser.Resource{Type: "aws_instance", Name: "web", Tags: tt.tags}, rule)
+			if len(violations) != len(tt.wantMessages) {
+				t.Fatalf("Expected %d violations, got %d: %+v", len(tt.wantMessages), len(violations), violations)
+			}
+			for i, want := range tt.wantMessages {
+				if violations[i].Message != want {
+					t.Errorf("Expected message %q, got %q", want, violations[i].Message)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckLookup_Combination(t *testing.T) {
+	rule := config.Rule{
+		Name: "region-account",
+		Lookup: &config.Lookup{
+			Key: "Environment",
+			Rows: []map[string]string{
+				{"Environment": "prod", "Region": "us-east-1", "Account": "111"},
+				{"Environment": "prod", "Region": "eu-west-1", "Account": "222"},
+			},
+		},
+	}
+	v := &Validator{}
+
+	resource := parser.Resource{Tags: map[string]string{"Environment": "prod", "Region": "us-east-1", "Account": "222"}}
+	violations := v.checkLookup(resource, rule)
+	want := "Tag combination Environment='prod', Account='222', Region='us-east-1' does not match any row of the lookup table"
+	if len(violations) != 1 || violations[0].Message != want {
+		t.Errorf("Expected violation %q, got %+v", want, violations)
+	}
+}
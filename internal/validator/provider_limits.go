@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tom-023/tftaglint/internal/config"
+	"github.com/tom-023/tftaglint/internal/parser"
+)
+
+// checkProviderLimits reports tags the resource's cloud provider would reject,
+// for the profiles enabled in global.provider_limits. Data sources are not
+// checked since their tags only filter existing resources.
+func (v *Validator) checkProviderLimits(resource parser.Resource) []Violation {
+	if resource.IsDataSource() {
+		return nil
+	}
+
+	var violations []Violation
+	for _, name := range v.config.Global.ProviderLimits {
+		limits, ok := config.LookupProviderLimits(name)
+		if !ok || !strings.HasPrefix(resource.Type, limits.ResourcePrefix) {
+			continue
+		}
+		for _, message := range checkLimits(limits, resource.Tags) {
+			violations = append(violations, Violation{
+				Rule:        "provider-limits-" + limits.Name,
+				Description: fmt.Sprintf("%s tag limits", limits.Title),
+				Severity:    v.config.Global.Severity.OrDefault(),
+				Resource:    resource,
+				Message:     message,
+			})
+		}
+	}
+
+	return violations
+}
+
+// checkLimits returns a message for each limit the tags exceed
+func checkLimits(limits *config.ProviderLimits, tags map[string]string) []string {
+	var messages []string
+
+	if limits.MaxTags > 0 && len(tags) > limits.MaxTags {
+		messages = append(messages, fmt.Sprintf("Too many tags: %d (%s allows at most %d)", len(tags), limits.Title, limits.MaxTags))
+	}
+
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := tags[key]
+
+		if limits.MaxKeyLength > 0 && utf8.RuneCountInString(key) > limits.MaxKeyLength {
+			messages = append(messages, fmt.Sprintf("Tag key '%s' is longer than %d characters (%s limit)", key, limits.MaxKeyLength, limits.Title))
+		}
+		if prefix, ok := limits.ReservedPrefix(key); ok {
+			messages = append(messages, fmt.Sprintf("Tag key '%s' uses the prefix '%s', which is reserved by %s", key, prefix, limits.Title))
+		}
+		if i := strings.IndexAny(key, limits.ForbiddenKeyChars); limits.ForbiddenKeyChars != "" && i >= 0 {
+			messages = append(messages, fmt.Sprintf("Tag key '%s' contains '%c', which %s does not allow (%s)", key, key[i], limits.Title, limits.ForbiddenKeyChars))
+		}
+		if limits.KeyPattern != nil && !limits.KeyPattern.MatchString(key) {
+			messages = append(messages, fmt.Sprintf("Tag key '%s' is not valid for %s: use %s", key, limits.Title, limits.KeyCharset))
+		}
+
+		if limits.MaxValueLength > 0 && utf8.RuneCountInString(value) > limits.MaxValueLength {
+			messages = append(messages, fmt.Sprintf("Value of tag %s is longer than %d characters (%s limit)", key, limits.MaxValueLength, limits.Title))
+		}
+		if limits.ValuePattern != nil && !limits.ValuePattern.MatchString(value) {
+			messages = append(messages, fmt.Sprintf("Value of tag %s is not valid for %s: '%s' (use %s)", key, limits.Title, value, limits.ValueCharset))
+		}
+	}
+
+	return messages
+}
@@ -0,0 +1,118 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/tom-023/tftaglint/internal/config"
+	"github.com/tom-023/tftaglint/internal/parser"
+)
+
+func manyTags(n int) map[string]string {
+	tags := make(map[string]string)
+	for i := 0; i < n; i++ {
+		tags[fmt.Sprintf("tag%02d", i)] = "value"
+	}
+	return tags
+}
+
+func TestCheckProviderLimits(t *testing.T) {
+	v := NewValidator(&config.Config{
+		Global: config.Global{ProviderLimits: []string{"aws", "azure", "gcp"}, Severity: config.SeverityWarning},
+	})
+
+	tests := []struct {
+		name         string
+		resource     parser.Resource
+		wantRule     string
+		wantMessages []string
+	}{
+		{
+			name:     "aws valid tags",
+			resource: parser.Resource{Type: "aws_instance", Tags: map[string]string{"Name": "web", "Owner": "alice@example.com"}},
+		},
+		{
+			name:         "aws too many tags",
+			resource:     parser.Resource{Type: "aws_instance", Tags: manyTags(51)},
+			wantRule:     "provider-limits-aws",
+			wantMessages: []string{"Too many tags: 51 (AWS allows at most 50)"},
+		},
+		{
+			name:     "aws key and value length and reserved prefix",
+			resource: parser.Resource{Type: "aws_s3_bucket", Tags: map[string]string{"AWS:Team": "platform", strings.Repeat("k", 129): strings.Repeat("v", 257)}},
+			wantRule: "provider-limits-aws",
+			wantMessages: []string{
+				"Tag key 'AWS:Team' uses the prefix 'aws:', which is reserved by AWS",
+				fmt.Sprintf("Tag key '%s' is longer than 128 characters (AWS limit)", strings.Repeat("k", 129)),
+				fmt.Sprintf("Value of tag %s is longer than 256 characters (AWS limit)", strings.Repeat("k", 129)),
+			},
+		},
+		{
+			name:         "aws counts characters, not bytes",
+			resource:     parser.Resource{Type: "aws_instance", Tags: map[string]string{"Name": strings.Repeat("é", 256)}},
+			wantMessages: nil,
+		},
+		{
+			name:         "azure forbidden key character",
+			resource:     parser.Resource{Type: "azurerm_resource_group", Tags: map[string]string{"cost/center": "cc-1", "owner": "a&b"}},
+			wantRule:     "provider-limits-azure",
+			wantMessages: []string{`Tag key 'cost/center' contains '/', which Azure does not allow (<>%&\?/)`},
+		},
+		{
+			name:     "gcp labels",
+			resource: parser.Resource{Type: "google_storage_bucket", Tags: map[string]string{"Team": "data", "env": "Prod", "1st": "ok", "cost_center": "cc-1"}},
+			wantRule: "provider-limits-gcp",
+			wantMessages: []string{
+				"Tag key '1st' is not valid for GCP: use lowercase letters, digits, '_' and '-', starting with a letter",
+				"Tag key 'Team' is not valid for GCP: use lowercase letters, digits, '_' and '-', starting with a letter",
+				"Value of tag env is not valid for GCP: 'Prod' (use lowercase letters, digits, '_' and '-')",
+			},
+		},
+		{
+			name:     "gcp key length",
+			resource: parser.Resource{Type: "google_compute_instance", Tags: map[string]string{strings.Repeat("a", 64): ""}},
+			wantRule: "provider-limits-gcp",
+			wantMessages: []string{
+				fmt.Sprintf("Tag key '%s' is longer than 63 characters (GCP limit)", strings.Repeat("a", 64)),
+			},
+		},
+		{
+			name:     "other providers are not checked",
+			resource: parser.Resource{Type: "kubernetes_namespace", Tags: map[string]string{"aws:Team": "Platform/Data"}},
+		},
+		{
+			name:     "data sources are not checked",
+			resource: parser.Resource{Type: "aws_ami", Mode: parser.ModeData, Tags: map[string]string{"aws:cloudformation:stack-name": "base"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := v.checkProviderLimits(tt.resource)
+			if len(violations) != len(tt.wantMessages) {
+				t.Fatalf("Expected %d violations, got %d: %+v", len(tt.wantMessages), len(violations), violations)
+			}
+			for i, want := range tt.wantMessages {
+				if violations[i].Message != want {
+					t.Errorf("Expected message %q, got %q", want, violations[i].Message)
+				}
+				if violations[i].Rule != tt.wantRule {
+					t.Errorf("Expected rule %q, got %q", tt.wantRule, violations[i].Rule)
+				}
+				if violations[i].Severity != config.SeverityWarning {
+					t.Errorf("Expected severity warning, got %q", violations[i].Severity)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckProviderLimits_NotEnabled(t *testing.T) {
+	v := NewValidator(&config.Config{Global: config.Global{ProviderLimits: []string{"gcp"}}})
+
+	resource := parser.Resource{Type: "aws_instance", Tags: manyTags(60)}
+	if violations := v.checkProviderLimits(resource); len(violations) != 0 {
+		t.Errorf("Expected no violations for a provider without an enabled profile, got %+v", violations)
+	}
+}
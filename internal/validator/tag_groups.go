@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tom-023/tftaglint/internal/config"
+	"github.com/tom-023/tftaglint/internal/parser"
+)
+
+// checkTagGroups checks the rule's required_any_of, required_one_of and
+// mutually_exclusive groups against the resource's tags
+func (v *Validator) checkTagGroups(resource parser.Resource, rule config.Rule) []Violation {
+	var violations []Violation
+
+	for _, group := range rule.RequiredAnyOf {
+		if len(presentTags(resource, group)) == 0 {
+			violations = append(violations, ruleViolation(rule, resource, fmt.Sprintf("Missing required tag: one of %s", strings.Join(group, ", "))))
+		}
+	}
+
+	for _, group := range rule.RequiredOneOf {
+		switch present := presentTags(resource, group); {
+		case len(present) == 0:
+			violations = append(violations, ruleViolation(rule, resource, fmt.Sprintf("Missing required tag: exactly one of %s", strings.Join(group, ", "))))
+		case len(present) > 1:
+			violations = append(violations, ruleViolation(rule, resource, exclusiveMessage(group, present)))
+		}
+	}
+
+	for _, group := range rule.MutuallyExclusive {
+		if present := presentTags(resource, group); len(present) > 1 {
+			violations = append(violations, ruleViolation(rule, resource, exclusiveMessage(group, present)))
+		}
+	}
+
+	return violations
+}
+
+// presentTags returns the tags of the group the resource has, in group order
+func presentTags(resource parser.Resource, group []string) []string {
+	var present []string
+	for _, tag := range group {
+		if _, exists := resource.Tags[tag]; exists {
+			present = append(present, tag)
+		}
+	}
+	return present
+}
+
+func exclusiveMessage(group, present []string) string {
+	return fmt.Sprintf("Only one of the tags %s may be set, found: %s", strings.Join(group, ", "), strings.Join(present, ", "))
+}
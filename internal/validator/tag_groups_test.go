@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/tom-023/tftaglint/internal/config"
+	"github.com/tom-023/tftaglint/internal/parser"
+)
+
+func TestCheckTagGroups(t *testing.T) {
+	rule := config.Rule{
+		Name:              "ownership",
+		RequiredAnyOf:     [][]string{{"Owner", "Team"}},
+		RequiredOneOf:     [][]string{{"CostCenter", "BillingAccount"}},
+		MutuallyExclusive: [][]string{{"DataClassification", "Confidentiality", "Sensitivity"}},
+	}
+	v := &Validator{}
+
+	tests := []struct {
+		name         string
+		tags         map[string]string
+		wantMessages []string
+	}{
+		{
+			name: "valid",
+			tags: map[string]string{"Team": "platform", "CostCenter": "CC-1", "DataClassification": "internal"},
+		},
+		{
+			name: "both of any_of are fine",
+			tags: map[string]string{"Owner": "alice", "Team": "platform", "BillingAccount": "BA-1"},
+		},
+		{
+			name: "missing groups",
+			tags: map[string]string{"Name": "web"},
+			wantMessages: []string{
+				"Missing required tag: one of Owner, Team",
+				"Missing required tag: exactly one of CostCenter, BillingAccount",
+			},
+		},
+		{
+			name: "several of one_of and mutually_exclusive",
+			tags: map[string]string{"Owner": "alice", "CostCenter": "CC-1", "BillingAccount": "BA-1", "Sensitivity": "high", "DataClassification": "internal"},
+			wantMessages: []string{
+				"Only one of the tags CostCenter, BillingAccount may be set, found: CostCenter, BillingAccount",
+				"Only one of the tags DataClassification, Confidentiality, Sensitivity may be set, found: DataClassification, Sensitivity",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := v.checkTagGroups(parser.Resource{Type: "aws_instance", Name: "web", Tags: tt.tags}, rule)
+			if len(violations) != len(tt.wantMessages) {
+				t.Fatalf("Expected %d violations, got %d: %+v", len(tt.wantMessages), len(violations), violations)
+			}
+			for i, want := range tt.wantMessages {
+				if violations[i].Message != want {
+					t.Errorf("Expected message %q, got %q", want, violations[i].Message)
+				}
+			}
+		})
+	}
+}
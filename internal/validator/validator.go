@@ -3,24 +3,58 @@ package validator
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/tom-023/tftaglint/internal/config"
 	"github.com/tom-023/tftaglint/internal/parser"
+	"github.com/tom-023/tftaglint/internal/tagdiff"
 )
 
 type Violation struct {
 	Rule        string
 	Description string
+	Severity    config.Severity
 	Resource    parser.Resource
 	Message     string
 }
 
+// ruleViolation creates a violation of rule by resource
+func ruleViolation(rule config.Rule, resource parser.Resource, message string) Violation {
+	return Violation{
+		Rule:        rule.Name,
+		Description: rule.Description,
+		Severity:    rule.Severity.OrDefault(),
+		Resource:    resource,
+		Message:     message,
+	}
+}
+
+// Drift is a resource whose tags were changed outside Terraform
+type Drift struct {
+	Resource   parser.Resource // Resource with the out-of-band tags
+	Diff       tagdiff.Diff
+	Violations []Violation // Violations of the out-of-band tags
+}
+
+// TagChange is a resource whose tags are changed by a plan
+type TagChange struct {
+	Resource   parser.Resource // Resource after the change
+	Diff       tagdiff.Diff
+	Violations []Violation // Protected tags removed or modified
+}
+
 type Validator struct {
 	config *config.Config
+	now    time.Time // Reference time for date checks
 }
 
 func NewValidator(cfg *config.Config) *Validator {
-	return &Validator{config: cfg}
+	return &Validator{config: cfg, now: time.Now()}
+}
+
+// SetNow sets the reference time that expiry dates are checked against
+func (v *Validator) SetNow(now time.Time) {
+	v.now = now
 }
 
 func (v *Validator) Validate(resources []parser.Resource) []Violation {
@@ -28,12 +62,18 @@ func (v *Validator) Validate(resources []parser.Resource) []Violation {
 
 	for _, resource := range resources {
 		// Check if resource type should be ignored
-		if v.shouldIgnoreResource(resource.Type) {
+		if v.shouldIgnoreResource(resource) {
 			continue
 		}
 
-		// Check global required tags
-		violations = append(violations, v.checkGlobalRequiredTags(resource)...)
+		// Check global required tags; data sources are only checked by rules
+		// that select them with a data. resource type pattern
+		if !resource.IsDataSource() {
+			violations = append(violations, v.checkGlobalRequiredTags(resource)...)
+		}
+
+		// Check provider tag limits
+		violations = append(violations, v.checkProviderLimits(resource)...)
 
 		// Check each rule
 		for _, rule := range v.config.Rules {
@@ -44,13 +84,92 @@ func (v *Validator) Validate(resources []parser.Resource) []Violation {
 	return violations
 }
 
-func (v *Validator) shouldIgnoreResource(resourceType string) bool {
-	for _, ignored := range v.config.Global.IgnoreResourceTypes {
-		if resourceType == ignored {
-			return true
+// ValidateDrift returns the resources from a plan's resource_drift section whose
+// tags were changed outside Terraform, along with any rule violations of the
+// out-of-band tags
+func (v *Validator) ValidateDrift(changes []parser.ResourceChange) []Drift {
+	var drifts []Drift
+
+	for _, change := range changes {
+		// Resources created or deleted outside Terraform have no tag drift
+		if change.Before == nil || change.After == nil {
+			continue
+		}
+		if v.shouldIgnoreResource(*change.After) {
+			continue
 		}
+
+		diff := tagdiff.Compare(change.Before.Tags, change.After.Tags)
+		if diff.IsEmpty() {
+			continue
+		}
+
+		drifts = append(drifts, Drift{
+			Resource:   *change.After,
+			Diff:       diff,
+			Violations: v.Validate([]parser.Resource{*change.After}),
+		})
 	}
-	return false
+
+	return drifts
+}
+
+// ValidateTagChanges returns the resources from a plan's resource_changes section
+// whose tags are changed by the plan, with violations for protected tags that
+// are removed or modified. Created and deleted resources are not reported.
+func (v *Validator) ValidateTagChanges(changes []parser.ResourceChange) []TagChange {
+	var tagChanges []TagChange
+
+	for _, change := range changes {
+		if change.Before == nil || change.After == nil {
+			continue
+		}
+		if v.shouldIgnoreResource(*change.After) {
+			continue
+		}
+
+		diff := tagdiff.Compare(change.Before.Tags, change.After.Tags)
+		if diff.IsEmpty() {
+			continue
+		}
+
+		tagChanges = append(tagChanges, TagChange{
+			Resource:   *change.After,
+			Diff:       diff,
+			Violations: v.checkProtectedTags(*change.Before, *change.After, diff),
+		})
+	}
+
+	return tagChanges
+}
+
+// checkProtectedTags reports protected tags removed or modified by a change.
+// Rules are matched against the resource before the change.
+func (v *Validator) checkProtectedTags(before, after parser.Resource, diff tagdiff.Diff) []Violation {
+	var violations []Violation
+
+	for _, rule := range v.config.Rules {
+		if len(rule.ProtectedTags) == 0 || !v.ruleApplies(before, rule) {
+			continue
+		}
+
+		for _, c := range diff.Removed {
+			if v.isTagInList(c.Key, rule.ProtectedTags) {
+				violations = append(violations, ruleViolation(rule, after, fmt.Sprintf("Protected tag removed: %s (was '%s')", c.Key, c.Before)))
+			}
+		}
+		for _, c := range diff.Modified {
+			if v.isTagInList(c.Key, rule.ProtectedTags) {
+				violations = append(violations, ruleViolation(rule, after, fmt.Sprintf("Protected tag changed: %s from '%s' to '%s'", c.Key, c.Before, c.After)))
+			}
+		}
+	}
+
+	return violations
+}
+
+func (v *Validator) shouldIgnoreResource(resource parser.Resource) bool {
+	return v.isResourceTypeInList(resource, v.config.Global.IgnoreResourceTypes)
 }
 
 func (v *Validator) checkGlobalRequiredTags(resource parser.Resource) []Violation {
@@ -61,6 +180,7 @@ func (v *Validator) checkGlobalRequiredTags(resource parser.Resource) []Violatio
 			violations = append(violations, Violation{
 				Rule:        "global-required-tags",
 				Description: "Global required tags",
+				Severity:    v.config.Global.Severity.OrDefault(),
 				Resource:    resource,
 				Message:     fmt.Sprintf("Missing required tag: %s", requiredTag),
 			})
@@ -73,65 +193,53 @@ func (v *Validator) checkGlobalRequiredTags(resource parser.Resource) []Violatio
 func (v *Validator) checkRule(resource parser.Resource, rule config.Rule) []Violation {
 	var violations []Violation
 
-	// Check if rule applies to this resource type
-	if len(rule.ResourceTypes) > 0 && !v.isResourceTypeInList(resource.Type, rule.ResourceTypes) {
-		return violations
-	}
-
-	// Check condition
-	if rule.Condition != nil && !v.checkCondition(resource, rule.Condition) {
+	if !v.ruleApplies(resource, rule) {
 		return violations
 	}
 
 	// Check required tags
 	for _, requiredTag := range rule.RequiredTags {
 		if _, exists := resource.Tags[requiredTag]; !exists {
-			violations = append(violations, Violation{
-				Rule:        rule.Name,
-				Description: rule.Description,
-				Resource:    resource,
-				Message:     fmt.Sprintf("Missing required tag: %s", requiredTag),
-			})
+			violations = append(violations, ruleViolation(rule, resource, fmt.Sprintf("Missing required tag: %s", requiredTag)))
 		}
 	}
 
 	// Check forbidden tags
 	for _, forbiddenTag := range rule.ForbiddenTags {
 		if _, exists := resource.Tags[forbiddenTag]; exists {
-			violations = append(violations, Violation{
-				Rule:        rule.Name,
-				Description: rule.Description,
-				Resource:    resource,
-				Message:     fmt.Sprintf("Forbidden tag found: %s", forbiddenTag),
-			})
+			violations = append(violations, ruleViolation(rule, resource, fmt.Sprintf("Forbidden tag found: %s", forbiddenTag)))
+		}
+	}
+
+	// Check tag groups
+	violations = append(violations, v.checkTagGroups(resource, rule)...)
+
+	// Check dependencies between tags
+	for _, dependency := range rule.Dependencies {
+		for _, message := range v.checkDependency(resource, dependency) {
+			violations = append(violations, ruleViolation(rule, resource, message))
 		}
 	}
 
 	// Check tag constraints
 	for _, constraint := range rule.TagConstraints {
 		if value, exists := resource.Tags[constraint.Tag]; exists {
-			if !v.isValueAllowed(value, constraint.AllowedValues) {
-				violations = append(violations, Violation{
-					Rule:        rule.Name,
-					Description: rule.Description,
-					Resource:    resource,
-					Message:     fmt.Sprintf("Invalid value for tag %s: '%s'. Allowed values: %s", 
-						constraint.Tag, value, strings.Join(constraint.AllowedValues, ", ")),
-				})
+			for _, message := range v.checkConstraint(constraint, value) {
+				violations = append(violations, ruleViolation(rule, resource, message))
 			}
 		}
 	}
 
+	// Check lookup table
+	if rule.Lookup != nil {
+		violations = append(violations, v.checkLookup(resource, rule)...)
+	}
+
 	// Check tag patterns
 	for tagName := range resource.Tags {
 		for _, pattern := range rule.TagPatterns {
 			if !pattern.Validate(tagName) {
-				violations = append(violations, Violation{
-					Rule:        rule.Name,
-					Description: rule.Description,
-					Resource:    resource,
-					Message:     fmt.Sprintf("Tag name '%s' does not match pattern: %s", tagName, pattern.Message),
-				})
+				violations = append(violations, ruleViolation(rule, resource, fmt.Sprintf("Tag name '%s' does not match pattern: %s", tagName, pattern.Message)))
 			}
 		}
 	}
@@ -139,9 +247,42 @@ func (v *Validator) checkRule(resource parser.Resource, rule config.Rule) []Viol
 	return violations
 }
 
-func (v *Validator) isResourceTypeInList(resourceType string, list []string) bool {
+// ruleApplies reports whether the rule's resource type filter and condition match the resource
+func (v *Validator) ruleApplies(resource parser.Resource, rule config.Rule) bool {
+	// Check if rule applies to this resource type. Data sources only match
+	// data. patterns, so rules without resource_types skip them.
+	if len(rule.ResourceTypes) == 0 && resource.IsDataSource() {
+		return false
+	}
+	if len(rule.ResourceTypes) > 0 && !v.isResourceTypeInList(resource, rule.ResourceTypes) {
+		return false
+	}
+	if v.isResourceTypeInList(resource, rule.ExcludeResourceTypes) {
+		return false
+	}
+
+	// Check condition
+	if rule.Condition != nil && !v.checkCondition(resource, rule.Condition) {
+		return false
+	}
+
+	return true
+}
+
+// isResourceTypeInList reports whether any resource type pattern in the list
+// matches the resource; see config.MatchResourceType
+func (v *Validator) isResourceTypeInList(resource parser.Resource, list []string) bool {
+	for _, pattern := range list {
+		if config.MatchResourceType(pattern, resource.IsDataSource(), resource.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *Validator) isTagInList(tag string, list []string) bool {
 	for _, t := range list {
-		if t == resourceType {
+		if t == tag {
 			return true
 		}
 	}
@@ -149,8 +290,43 @@ func (v *Validator) isResourceTypeInList(resourceType string, list []string) boo
 }
 
 func (v *Validator) checkCondition(resource parser.Resource, condition *config.Condition) bool {
+	switch {
+	case condition.All != nil:
+		for i := range condition.All {
+			if !v.checkCondition(resource, &condition.All[i]) {
+				return false
+			}
+		}
+		return true
+	case condition.Any != nil:
+		for i := range condition.Any {
+			if v.checkCondition(resource, &condition.Any[i]) {
+				return true
+			}
+		}
+		return false
+	case condition.Not != nil:
+		return !v.checkCondition(resource, condition.Not)
+	}
+
 	value, exists := resource.Tags[condition.Tag]
-	return exists && value == condition.Value
+	switch {
+	case condition.Exists:
+		return exists
+	case condition.NotExists:
+		return !exists
+	case len(condition.In) > 0:
+		return exists && v.isValueAllowed(value, condition.In)
+	case len(condition.NotIn) > 0:
+		return !exists || !v.isValueAllowed(value, condition.NotIn)
+	case condition.Matches != "":
+		// MatchesRegex is compiled when the config is loaded
+		return exists && condition.MatchesRegex != nil && condition.MatchesRegex.MatchString(value)
+	case condition.Prefix != "":
+		return exists && strings.HasPrefix(value, condition.Prefix)
+	default:
+		return exists && value == condition.Value
+	}
 }
 
 func (v *Validator) isValueAllowed(value string, allowedValues []string) bool {
@@ -325,6 +325,46 @@ func TestValidate(t *testing.T) {
 			},
 			wantViolations: 0,
 		},
+		{
+			name: "severity from rule and global settings",
+			config: &config.Config{
+				Rules: []config.Rule{
+					{
+						Name:         "owner",
+						RequiredTags: []string{"Owner"},
+						Severity:     config.SeverityWarning,
+					},
+					{
+						Name:          "temp",
+						ForbiddenTags: []string{"Temp"},
+					},
+				},
+				Global: config.Global{
+					AlwaysRequiredTags: []string{"Name"},
+					Severity:           config.SeverityInfo,
+				},
+			},
+			resources: []parser.Resource{
+				{
+					Type: "aws_instance",
+					Name: "web",
+					Tags: map[string]string{"Temp": "yes"},
+				},
+			},
+			wantViolations: 3,
+			checkViolations: func(t *testing.T, violations []Violation) {
+				want := map[string]config.Severity{
+					"global-required-tags": config.SeverityInfo,
+					"owner":                config.SeverityWarning,
+					"temp":                 config.SeverityError,
+				}
+				for _, v := range violations {
+					if v.Severity != want[v.Rule] {
+						t.Errorf("Expected severity %s for %s, got %s", want[v.Rule], v.Rule, v.Severity)
+					}
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -360,6 +400,137 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidateDrift(t *testing.T) {
+	v := NewValidator(&config.Config{
+		Global: config.Global{
+			AlwaysRequiredTags:  []string{"Owner"},
+			IgnoreResourceTypes: []string{"aws_iam_role"},
+		},
+	})
+
+	resource := func(resourceType string, tags map[string]string) *parser.Resource {
+		return &parser.Resource{Type: resourceType, Name: "example", Tags: tags}
+	}
+
+	changes := []parser.ResourceChange{
+		{
+			// Owner removed in the console: drift with a violation
+			Before: resource("aws_instance", map[string]string{"Owner": "team-a", "Name": "web"}),
+			After:  resource("aws_instance", map[string]string{"Name": "web"}),
+		},
+		{
+			// Tag added in the console: drift without violations
+			Before: resource("aws_s3_bucket", map[string]string{"Owner": "team-a"}),
+			After:  resource("aws_s3_bucket", map[string]string{"Owner": "team-a", "Temp": "yes"}),
+		},
+		{
+			// Non-tag attributes drifted only
+			Before: resource("aws_instance", map[string]string{"Owner": "team-a"}),
+			After:  resource("aws_instance", map[string]string{"Owner": "team-a"}),
+		},
+		{
+			// Deleted outside Terraform
+			Before: resource("aws_instance", map[string]string{"Owner": "team-a"}),
+		},
+		{
+			// Ignored resource type
+			Before: resource("aws_iam_role", map[string]string{}),
+			After:  resource("aws_iam_role", map[string]string{"Owner": "x"}),
+		},
+	}
+
+	drifts := v.ValidateDrift(changes)
+	if len(drifts) != 2 {
+		t.Fatalf("Expected 2 drifts, got %d", len(drifts))
+	}
+
+	if len(drifts[0].Diff.Removed) != 1 || drifts[0].Diff.Removed[0].Key != "Owner" {
+		t.Errorf("Expected Owner to be removed, got %+v", drifts[0].Diff)
+	}
+	if len(drifts[0].Violations) != 1 || drifts[0].Violations[0].Message != "Missing required tag: Owner" {
+		t.Errorf("Expected missing Owner violation, got %+v", drifts[0].Violations)
+	}
+
+	if len(drifts[1].Diff.Added) != 1 || drifts[1].Diff.Added[0].Key != "Temp" {
+		t.Errorf("Expected Temp to be added, got %+v", drifts[1].Diff)
+	}
+	if len(drifts[1].Violations) != 0 {
+		t.Errorf("Expected no violations, got %+v", drifts[1].Violations)
+	}
+}
+
+func TestValidateTagChanges(t *testing.T) {
+	v := NewValidator(&config.Config{
+		Rules: []config.Rule{
+			{
+				Name:          "finance-tags",
+				Description:   "Finance tags must not be removed or changed",
+				ProtectedTags: []string{"CostCenter", "Owner"},
+			},
+			{
+				Name:          "production-only",
+				ProtectedTags: []string{"Environment"},
+				Condition:     &config.Condition{Tag: "Environment", Value: "production"},
+			},
+		},
+	})
+
+	resource := func(tags map[string]string) *parser.Resource {
+		return &parser.Resource{Type: "aws_instance", Name: "web", Tags: tags}
+	}
+
+	changes := []parser.ResourceChange{
+		{
+			Before: resource(map[string]string{"CostCenter": "CC-1", "Owner": "a", "Name": "web"}),
+			After:  resource(map[string]string{"Owner": "b", "Name": "web-1"}),
+		},
+		{
+			// Condition is evaluated on the tags before the change
+			Before: resource(map[string]string{"Environment": "production"}),
+			After:  resource(map[string]string{"Environment": "staging"}),
+		},
+		{
+			Before: resource(map[string]string{"Environment": "staging"}),
+			After:  resource(map[string]string{"Environment": "development", "New": "tag"}),
+		},
+		{
+			Before: resource(map[string]string{"CostCenter": "CC-1"}),
+			After:  resource(map[string]string{"CostCenter": "CC-1"}),
+		},
+		{
+			// Created resource
+			After: resource(map[string]string{"Name": "new"}),
+		},
+	}
+
+	tagChanges := v.ValidateTagChanges(changes)
+	if len(tagChanges) != 3 {
+		t.Fatalf("Expected 3 tag changes, got %d", len(tagChanges))
+	}
+
+	wantMessages := [][]string{
+		{"Protected tag removed: CostCenter (was 'CC-1')", "Protected tag changed: Owner from 'a' to 'b'"},
+		{"Protected tag changed: Environment from 'production' to 'staging'"},
+		nil,
+	}
+	for i, want := range wantMessages {
+		var got []string
+		for _, violation := range tagChanges[i].Violations {
+			got = append(got, violation.Message)
+		}
+		if strings.Join(got, "|") != strings.Join(want, "|") {
+			t.Errorf("Change %d: expected violations %v, got %v", i, want, got)
+		}
+	}
+
+	if tagChanges[0].Violations[0].Rule != "finance-tags" {
+		t.Errorf("Expected rule finance-tags, got %s", tagChanges[0].Violations[0].Rule)
+	}
+	if len(tagChanges[2].Diff.Added) != 1 || len(tagChanges[2].Diff.Modified) != 1 {
+		t.Errorf("Expected one added and one modified tag, got %+v", tagChanges[2].Diff)
+	}
+}
+
 func TestShouldIgnoreResource(t *testing.T) {
 	v := &Validator{
 		config: &config.Config{
@@ -381,7 +552,7 @@ func TestShouldIgnoreResource(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.resourceType, func(t *testing.T) {
-			got := v.shouldIgnoreResource(tt.resourceType)
+			got := v.shouldIgnoreResource(parser.Resource{Type: tt.resourceType})
 			if got != tt.wantIgnore {
 				t.Errorf("shouldIgnoreResource(%s) = %v, want %v", tt.resourceType, got, tt.wantIgnore)
 			}
@@ -389,6 +560,83 @@ func TestShouldIgnoreResource(t *testing.T) {
 	}
 }
 
+func TestShouldIgnoreResource_Patterns(t *testing.T) {
+	v := &Validator{
+		config: &config.Config{
+			Global: config.Global{
+				IgnoreResourceTypes: []string{"aws_iam_*", "/^aws_(sqs|sns)_queue_policy$/", "data.*"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		resource   parser.Resource
+		wantIgnore bool
+	}{
+		{"glob", parser.Resource{Type: "aws_iam_role_policy_attachment"}, true},
+		{"regex", parser.Resource{Type: "aws_sqs_queue_policy"}, true},
+		{"not matched", parser.Resource{Type: "aws_sqs_queue"}, false},
+		{"data source", parser.Resource{Type: "aws_ami", Mode: parser.ModeData}, true},
+		{"managed resource not matched by data pattern", parser.Resource{Type: "aws_instance", Mode: parser.ModeManaged}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.shouldIgnoreResource(tt.resource); got != tt.wantIgnore {
+				t.Errorf("shouldIgnoreResource(%+v) = %v, want %v", tt.resource, got, tt.wantIgnore)
+			}
+		})
+	}
+}
+
+func TestRuleApplies_ResourceTypes(t *testing.T) {
+	v := &Validator{}
+	rule := config.Rule{
+		Name:                 "aws",
+		ResourceTypes:        []string{"aws_*", "data.aws_vpc"},
+		ExcludeResourceTypes: []string{"aws_iam_*_attachment"},
+	}
+
+	tests := []struct {
+		name     string
+		resource parser.Resource
+		want     bool
+	}{
+		{"matched by glob", parser.Resource{Type: "aws_s3_bucket"}, true},
+		{"excluded", parser.Resource{Type: "aws_iam_role_policy_attachment"}, false},
+		{"other provider", parser.Resource{Type: "google_storage_bucket"}, false},
+		{"data source listed", parser.Resource{Type: "aws_vpc", Mode: parser.ModeData}, true},
+		{"data source not listed", parser.Resource{Type: "aws_ami", Mode: parser.ModeData}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.ruleApplies(tt.resource, rule); got != tt.want {
+				t.Errorf("ruleApplies(%+v) = %v, want %v", tt.resource, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_DataSources(t *testing.T) {
+	v := NewValidator(&config.Config{
+		Rules: []config.Rule{
+			{Name: "owner", RequiredTags: []string{"Owner"}},
+			{Name: "ami-team", ResourceTypes: []string{"data.aws_ami"}, RequiredTags: []string{"Team"}},
+		},
+		Global: config.Global{AlwaysRequiredTags: []string{"Owner"}},
+	})
+
+	violations := v.Validate([]parser.Resource{
+		{Type: "aws_ami", Name: "ubuntu", Mode: parser.ModeData},
+		{Type: "aws_vpc", Name: "main", Mode: parser.ModeData},
+	})
+	if len(violations) != 1 || violations[0].Rule != "ami-team" {
+		t.Errorf("Expected data sources to be checked only by the rule selecting them, got %+v", violations)
+	}
+}
+
 func TestCheckCondition(t *testing.T) {
 	v := &Validator{}
 
@@ -435,6 +683,159 @@ func TestCheckCondition(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "in",
+			resource: parser.Resource{
+				Tags: map[string]string{
+					"Environment": "staging",
+					"DataClass":   "pii",
+					"Team":        "platform-core",
+				},
+			},
+			condition: &config.Condition{Tag: "Environment", In: []string{"staging", "production"}},
+			want:      true,
+		},
+		{
+			name: "in - value not listed",
+			resource: parser.Resource{
+				Tags: map[string]string{
+					"Environment": "staging",
+					"DataClass":   "pii",
+					"Team":        "platform-core",
+				},
+			},
+			condition: &config.Condition{Tag: "Environment", In: []string{"production"}},
+			want:      false,
+		},
+		{
+			name: "not_in",
+			resource: parser.Resource{
+				Tags: map[string]string{
+					"Environment": "staging",
+					"DataClass":   "pii",
+					"Team":        "platform-core",
+				},
+			},
+			condition: &config.Condition{Tag: "Environment", NotIn: []string{"production"}},
+			want:      true,
+		},
+		{
+			name: "not_in - tag missing",
+			resource: parser.Resource{
+				Tags: map[string]string{
+					"Environment": "staging",
+					"DataClass":   "pii",
+					"Team":        "platform-core",
+				},
+			},
+			condition: &config.Condition{Tag: "Owner", NotIn: []string{"nobody"}},
+			want:      true,
+		},
+		{
+			name: "matches",
+			resource: parser.Resource{
+				Tags: map[string]string{
+					"Environment": "staging",
+					"DataClass":   "pii",
+					"Team":        "platform-core",
+				},
+			},
+			condition: &config.Condition{Tag: "Team", Matches: "^platform-", MatchesRegex: regexp.MustCompile("^platform-")},
+			want:      true,
+		},
+		{
+			name: "prefix",
+			resource: parser.Resource{
+				Tags: map[string]string{
+					"Environment": "staging",
+					"DataClass":   "pii",
+					"Team":        "platform-core",
+				},
+			},
+			condition: &config.Condition{Tag: "Team", Prefix: "data-"},
+			want:      false,
+		},
+		{
+			name: "exists",
+			resource: parser.Resource{
+				Tags: map[string]string{
+					"Environment": "staging",
+					"DataClass":   "pii",
+					"Team":        "platform-core",
+				},
+			},
+			condition: &config.Condition{Tag: "DataClass", Exists: true},
+			want:      true,
+		},
+		{
+			name: "not_exists",
+			resource: parser.Resource{
+				Tags: map[string]string{
+					"Environment": "staging",
+					"DataClass":   "pii",
+					"Team":        "platform-core",
+				},
+			},
+			condition: &config.Condition{Tag: "DataClass", NotExists: true},
+			want:      false,
+		},
+		{
+			name: "all",
+			resource: parser.Resource{
+				Tags: map[string]string{
+					"Environment": "staging",
+					"DataClass":   "pii",
+					"Team":        "platform-core",
+				},
+			},
+			condition: &config.Condition{All: []config.Condition{
+				{Tag: "Environment", In: []string{"staging", "production"}},
+				{Tag: "DataClass", Exists: true},
+			}},
+			want: true,
+		},
+		{
+			name: "all - one condition not met",
+			resource: parser.Resource{
+				Tags: map[string]string{
+					"Environment": "staging",
+					"DataClass":   "pii",
+					"Team":        "platform-core",
+				},
+			},
+			condition: &config.Condition{All: []config.Condition{
+				{Tag: "Environment", In: []string{"staging", "production"}},
+				{Tag: "BackupPolicy", Exists: true},
+			}},
+			want: false,
+		},
+		{
+			name: "any",
+			resource: parser.Resource{
+				Tags: map[string]string{
+					"Environment": "staging",
+					"DataClass":   "pii",
+					"Team":        "platform-core",
+				},
+			},
+			condition: &config.Condition{Any: []config.Condition{
+				{Tag: "Environment", Value: "production"},
+				{Tag: "DataClass", Value: "pii"},
+			}},
+			want: true,
+		},
+		{
+			name: "not",
+			resource: parser.Resource{
+				Tags: map[string]string{
+					"Environment": "staging",
+					"DataClass":   "pii",
+					"Team":        "platform-core",
+				},
+			},
+			condition: &config.Condition{Not: &config.Condition{Tag: "Environment", Value: "staging"}},
+			want:      false,
+		},
 	}
 
 	for _, tt := range tests {
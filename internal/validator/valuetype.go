@@ -0,0 +1,134 @@
+package validator
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tom-023/tftaglint/internal/config"
+)
+
+// semverPattern is the regular expression recommended by semver.org
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// checkValueType returns why the value is not of the constraint's type, or an
+// empty string if it is
+func (v *Validator) checkValueType(constraint config.TagConstraint, value string) string {
+	switch constraint.Type {
+	case config.TypeEmail:
+		return checkEmail(value, constraint.Domains)
+	case config.TypeURL:
+		return checkURL(value, constraint.Schemes)
+	case config.TypeInteger:
+		return checkInteger(value, constraint.Min, constraint.Max)
+	case config.TypeBoolean:
+		if value != "true" && value != "false" {
+			return "is not a boolean (use true or false)"
+		}
+	case config.TypeDate, config.TypeExpiry:
+		return v.checkDate(constraint, value)
+	case config.TypeSemver:
+		if !semverPattern.MatchString(value) {
+			return "is not a semantic version (e.g. 1.2.3)"
+		}
+	}
+	return ""
+}
+
+// checkDate parses the value with the constraint's date formats and checks it
+// against the reference time: expiry dates must not have passed, and no date
+// may be more than max_days_ahead days in the future
+func (v *Validator) checkDate(constraint config.TagConstraint, value string) string {
+	formats := constraint.Formats
+	if len(formats) == 0 {
+		formats = []string{config.DefaultDateFormat}
+	}
+
+	var date time.Time
+	parsed := false
+	for _, format := range formats {
+		layout, ok := config.DateLayout(format)
+		if !ok {
+			continue
+		}
+		if t, err := time.Parse(layout, value); err == nil {
+			date, parsed = t, true
+			break
+		}
+	}
+	if !parsed {
+		return fmt.Sprintf("is not a date in %s format", strings.Join(formats, " or "))
+	}
+
+	days := daysBetween(v.now, date)
+	if constraint.Type == config.TypeExpiry && days < 0 {
+		return fmt.Sprintf("expired %d day(s) ago", -days)
+	}
+	if constraint.MaxDaysAhead != nil && days > *constraint.MaxDaysAhead {
+		return fmt.Sprintf("is %d days ahead, more than the maximum of %d", days, *constraint.MaxDaysAhead)
+	}
+	return ""
+}
+
+// daysBetween returns the number of calendar days from the date of from to the
+// date of to
+func daysBetween(from, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
+func checkEmail(value string, domains []string) string {
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Address != value {
+		return "is not a valid email address"
+	}
+	if len(domains) == 0 {
+		return ""
+	}
+
+	domain := value[strings.LastIndex(value, "@")+1:]
+	for _, allowed := range domains {
+		if strings.EqualFold(domain, allowed) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("has email domain %s, allowed domains: %s", domain, strings.Join(domains, ", "))
+}
+
+func checkURL(value string, schemes []string) string {
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "is not a valid URL"
+	}
+	if len(schemes) == 0 {
+		return ""
+	}
+
+	for _, allowed := range schemes {
+		if strings.EqualFold(u.Scheme, allowed) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("has URL scheme %s, allowed schemes: %s", u.Scheme, strings.Join(schemes, ", "))
+}
+
+func checkInteger(value string, min, max *int64) string {
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return "is not an integer"
+	}
+	if min != nil && n < *min {
+		return fmt.Sprintf("is less than the minimum of %d", *min)
+	}
+	if max != nil && n > *max {
+		return fmt.Sprintf("is greater than the maximum of %d", *max)
+	}
+	return ""
+}
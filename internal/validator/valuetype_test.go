@@ -0,0 +1,195 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tom-023/tftaglint/internal/config"
+)
+
+func TestCheckValueType(t *testing.T) {
+	v := &Validator{}
+	one, max := int64(1), int64(3650)
+
+	tests := []struct {
+		name       string
+		constraint config.TagConstraint
+		value      string
+		want       string
+	}{
+		{
+			name:       "valid email",
+			constraint: config.TagConstraint{Type: config.TypeEmail},
+			value:      "team-a@example.com",
+		},
+		{
+			name:       "invalid email",
+			constraint: config.TagConstraint{Type: config.TypeEmail},
+			value:      "team-a",
+			want:       "is not a valid email address",
+		},
+		{
+			name:       "email with display name",
+			constraint: config.TagConstraint{Type: config.TypeEmail},
+			value:      "Team A <team-a@example.com>",
+			want:       "is not a valid email address",
+		},
+		{
+			name:       "email domain allowed",
+			constraint: config.TagConstraint{Type: config.TypeEmail, Domains: []string{"example.com"}},
+			value:      "team-a@Example.com",
+		},
+		{
+			name:       "email domain not allowed",
+			constraint: config.TagConstraint{Type: config.TypeEmail, Domains: []string{"example.com", "example.org"}},
+			value:      "team-a@gmail.com",
+			want:       "has email domain gmail.com, allowed domains: example.com, example.org",
+		},
+		{
+			name:       "valid URL",
+			constraint: config.TagConstraint{Type: config.TypeURL},
+			value:      "https://wiki.example.com/runbooks/web",
+		},
+		{
+			name:       "relative URL",
+			constraint: config.TagConstraint{Type: config.TypeURL},
+			value:      "/runbooks/web",
+			want:       "is not a valid URL",
+		},
+		{
+			name:       "URL scheme not allowed",
+			constraint: config.TagConstraint{Type: config.TypeURL, Schemes: []string{"https"}},
+			value:      "http://wiki.example.com",
+			want:       "has URL scheme http, allowed schemes: https",
+		},
+		{
+			name:       "integer in range",
+			constraint: config.TagConstraint{Type: config.TypeInteger, Min: &one, Max: &max},
+			value:      "90",
+		},
+		{
+			name:       "not an integer",
+			constraint: config.TagConstraint{Type: config.TypeInteger},
+			value:      "90d",
+			want:       "is not an integer",
+		},
+		{
+			name:       "integer below minimum",
+			constraint: config.TagConstraint{Type: config.TypeInteger, Min: &one, Max: &max},
+			value:      "0",
+			want:       "is less than the minimum of 1",
+		},
+		{
+			name:       "integer above maximum",
+			constraint: config.TagConstraint{Type: config.TypeInteger, Min: &one, Max: &max},
+			value:      "4000",
+			want:       "is greater than the maximum of 3650",
+		},
+		{
+			name:       "boolean",
+			constraint: config.TagConstraint{Type: config.TypeBoolean},
+			value:      "false",
+		},
+		{
+			name:       "not a boolean",
+			constraint: config.TagConstraint{Type: config.TypeBoolean},
+			value:      "yes",
+			want:       "is not a boolean (use true or false)",
+		},
+		{
+			name:       "date",
+			constraint: config.TagConstraint{Type: config.TypeDate},
+			value:      "2024-02-29",
+		},
+		{
+			name:       "invalid date",
+			constraint: config.TagConstraint{Type: config.TypeDate},
+			value:      "2023-02-29",
+			want:       "is not a date in YYYY-MM-DD format",
+		},
+		{
+			name:       "semver with prerelease",
+			constraint: config.TagConstraint{Type: config.TypeSemver},
+			value:      "1.2.3-rc.1+build.5",
+		},
+		{
+			name:       "not semver",
+			constraint: config.TagConstraint{Type: config.TypeSemver},
+			value:      "v1.2",
+			want:       "is not a semantic version (e.g. 1.2.3)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.checkValueType(tt.constraint, tt.value); got != tt.want {
+				t.Errorf("checkValueType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckValueType_Dates(t *testing.T) {
+	v := NewValidator(nil)
+	v.SetNow(time.Date(2024, 6, 15, 18, 30, 0, 0, time.UTC))
+	thirty := 30
+
+	tests := []struct {
+		name       string
+		constraint config.TagConstraint
+		value      string
+		want       string
+	}{
+		{
+			name:       "expiry today",
+			constraint: config.TagConstraint{Type: config.TypeExpiry},
+			value:      "2024-06-15",
+		},
+		{
+			name:       "expired",
+			constraint: config.TagConstraint{Type: config.TypeExpiry},
+			value:      "2024-06-10",
+			want:       "expired 5 day(s) ago",
+		},
+		{
+			name:       "past date is not checked for expiry",
+			constraint: config.TagConstraint{Type: config.TypeDate},
+			value:      "2020-01-01",
+		},
+		{
+			name:       "within max_days_ahead",
+			constraint: config.TagConstraint{Type: config.TypeExpiry, MaxDaysAhead: &thirty},
+			value:      "2024-07-15",
+		},
+		{
+			name:       "beyond max_days_ahead",
+			constraint: config.TagConstraint{Type: config.TypeExpiry, MaxDaysAhead: &thirty},
+			value:      "2024-07-16",
+			want:       "is 31 days ahead, more than the maximum of 30",
+		},
+		{
+			name:       "alternative format",
+			constraint: config.TagConstraint{Type: config.TypeExpiry, Formats: []string{"YYYY-MM-DD", "DD/MM/YYYY"}},
+			value:      "01/07/2024",
+		},
+		{
+			name:       "rfc3339",
+			constraint: config.TagConstraint{Type: config.TypeExpiry, Formats: []string{"rfc3339"}},
+			value:      "2024-06-20T00:00:00Z",
+		},
+		{
+			name:       "no format matches",
+			constraint: config.TagConstraint{Type: config.TypeExpiry, Formats: []string{"YYYY-MM-DD", "DD/MM/YYYY"}},
+			value:      "July 1st",
+			want:       "is not a date in YYYY-MM-DD or DD/MM/YYYY format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.checkValueType(tt.constraint, tt.value); got != tt.want {
+				t.Errorf("checkValueType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}